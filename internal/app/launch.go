@@ -1,19 +1,36 @@
 package app
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
 	"github.com/michaelhass/gitglance/internal/core/logger"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
 )
 
+// minBackgroundFetchInterval is the shortest supported background fetch interval.
+// Shorter intervals are raised to it, to not flood the remote with requests.
+const minBackgroundFetchInterval time.Duration = time.Second * 30
+
 type Option func(opts *options)
 
 type options struct {
 	logger logger.Logger
+	// backgroundFetchInterval is the interval to fetch the upstream remote.
+	// The background fetch is disabled if it is not positive.
+	backgroundFetchInterval time.Duration
+	// diffLimit is the size of diffs, which are collapsed until they are loaded anyway.
+	diffLimit diff.Limit
 }
 
 func newOptions() *options {
 	return &options{
-		logger: logger.NewEmptyLogger(),
+		logger:                  logger.NewEmptyLogger(),
+		backgroundFetchInterval: configuredBackgroundFetchInterval(),
+		diffLimit:               configuredDiffLimit(),
 	}
 }
 
@@ -34,15 +51,72 @@ func WithDebugLogger() Option {
 	}
 }
 
+// WithBackgroundFetchInterval periodically fetches the upstream remote of the current branch.
+// It overrides the interval configured with `git config gitglance.fetchInterval`.
+// A non positive interval disables the background fetch.
+func WithBackgroundFetchInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.backgroundFetchInterval = interval
+	}
+}
+
+// WithDiffLimit collapses diffs larger than the limit until they are loaded anyway.
+// It overrides the limit configured with `git config gitglance.diffMaxBytes` and `gitglance.diffMaxLines`.
+func WithDiffLimit(limit diff.Limit) Option {
+	return func(opts *options) {
+		opts.diffLimit = limit
+	}
+}
+
 func Launch(opts ...Option) error {
 	appOpts := newOptions()
 	for _, opt := range opts {
 		opt(appOpts)
 	}
 
+	if appOpts.backgroundFetchInterval > 0 {
+		appOpts.backgroundFetchInterval = max(appOpts.backgroundFetchInterval, minBackgroundFetchInterval)
+	}
+
 	defer appOpts.logger.Close()
-	if _, err := tea.NewProgram(newModel(appOpts.logger), tea.WithAltScreen()).Run(); err != nil {
+	appModel := newModel(appOpts.logger, appOpts.backgroundFetchInterval, appOpts.diffLimit)
+	if _, err := tea.NewProgram(appModel, tea.WithAltScreen()).Run(); err != nil {
 		return err
 	}
 	return nil
 }
+
+// configuredBackgroundFetchInterval reads `git config gitglance.fetchInterval`.
+// The value is either a duration like `5m` or a number of seconds.
+func configuredBackgroundFetchInterval() time.Duration {
+	value, err := git.BackgroundFetchIntervalValue()
+	if err != nil {
+		return 0
+	}
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// configuredDiffLimit reads `git config gitglance.diffMaxBytes` and `gitglance.diffMaxLines`.
+// The default limit is used for values, which aren't configured. A value of 0 disables the limit.
+func configuredDiffLimit() diff.Limit {
+	limit := diff.DefaultLimit
+	if value, err := git.DiffMaxBytesValue(); err == nil {
+		if bytes, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			limit.Bytes = bytes
+		}
+	}
+	if value, err := git.DiffMaxLinesValue(); err == nil {
+		if lines, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			limit.Lines = lines
+		}
+	}
+	return limit
+}
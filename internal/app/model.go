@@ -10,6 +10,9 @@ import (
 	"github.com/michaelhass/gitglance/internal/core/logger"
 	"github.com/michaelhass/gitglance/internal/core/refresh"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+	"github.com/michaelhass/gitglance/internal/domain/remote"
+	"github.com/michaelhass/gitglance/internal/domain/worktree"
 	"github.com/michaelhass/gitglance/internal/page/status"
 )
 
@@ -27,23 +30,33 @@ type model struct {
 	// Only the last dialog will receive messages and will be rendered
 	dialogs []dialog.Model
 
+	// backgroundFetchInterval is the duration when the upstream remote is fetched.
+	// The background fetch is disabled if it is not positive.
+	backgroundFetchInterval time.Duration
+	// isFetching is set while a background fetch runs, so that ticks don't start another one.
+	isFetching bool
+
 	isReady       bool
 	width, height int
 
 	logger logger.Logger
 }
 
-func newModel(logger logger.Logger) model {
+func newModel(logger logger.Logger, backgroundFetchInterval time.Duration, diffLimit diff.Limit) model {
 	return model{
-		status: status.New(),
-		logger: logger,
+		status:                  status.New(diffLimit),
+		backgroundFetchInterval: backgroundFetchInterval,
+		logger:                  logger,
 	}
 }
 
 func (m model) Init() tea.Cmd {
 	return tea.Sequence(
 		m.status.Init(),
-		refresh.Schedule(refreshInterval),
+		tea.Batch(
+			refresh.Schedule(refreshInterval),
+			m.scheduleBackgroundFetch(),
+		),
 	)
 }
 
@@ -74,6 +87,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		dialog = dialog.SetSize(m.width, m.height)
 		m.dialogs = append(m.dialogs, dialog)
 		cmds = append(cmds, dialog.Init())
+	case dialog.ReplaceMsg:
+		dialog := msg.Dialog
+		dialog = dialog.SetSize(m.width, m.height)
+		m = m.removedTopDialog()
+		m.dialogs = append(m.dialogs, dialog)
+		cmds = append(cmds, dialog.Init())
 	case dialog.CloseMsg:
 		if d, ok := m.topDialog(); ok {
 			_, cmd := d.Update(msg)
@@ -82,6 +101,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case refresh.Msg:
 		cmds = append(cmds, refresh.Schedule(refreshInterval))
+	case refresh.FetchMsg:
+		cmds = append(cmds, m.scheduleBackgroundFetch())
+		if !m.isFetching {
+			m.isFetching = true
+			cmds = append(cmds, remote.FetchInBackground)
+		}
+	case remote.BackgroundFetchedMsg:
+		// The status is refreshed, even if a dialog is showing.
+		m.isFetching = false
+		status, cmd := m.status.Update(msg)
+		m.status = status
+		return m, cmd
+	case worktree.CmdExecuted:
+		if msg.CmdType == worktree.SwitchedCmdType && msg.Err() == nil {
+			return m.reloaded()
+		}
 	}
 
 	if m.isDialogShowing() {
@@ -108,6 +143,21 @@ func (m model) View() string {
 	return m.status.View()
 }
 
+// reloaded closes all dialogs and recreates the status with its options, e.g. after
+// switching the work tree.
+func (m model) reloaded() (model, tea.Cmd) {
+	m.dialogs = nil
+	m.status = m.status.Reloaded().SetSize(m.width, m.height)
+	return m, m.status.Init()
+}
+
+func (m model) scheduleBackgroundFetch() tea.Cmd {
+	if m.backgroundFetchInterval <= 0 {
+		return nil
+	}
+	return refresh.ScheduleFetch(m.backgroundFetchInterval)
+}
+
 func (m model) isDialogShowing() bool {
 	return len(m.dialogs) > 0
 }
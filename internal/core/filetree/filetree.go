@@ -0,0 +1,124 @@
+// Package filetree groups file paths into a tree of directories.
+package filetree
+
+import (
+	"sort"
+	"strings"
+)
+
+const separator = "/"
+
+// Node is a directory or a file of the tree.
+type Node struct {
+	// Name to display. Directories with a single directory as child are
+	// compacted into one node, which is named by the chain, e.g. `a/b/c`.
+	Name string
+	// Path of the file or, with a trailing slash, of the directory.
+	Path string
+	// Index of the file in the paths the tree was built from. -1 for directories.
+	Index    int
+	Children []*Node
+}
+
+func (n *Node) IsDir() bool {
+	return n.Index < 0
+}
+
+// FileIndices returns the indices of all files below the node, in tree order.
+func (n *Node) FileIndices() []int {
+	if !n.IsDir() {
+		return []int{n.Index}
+	}
+	var indices []int
+	for _, child := range n.Children {
+		indices = append(indices, child.FileIndices()...)
+	}
+	return indices
+}
+
+// Build groups the paths by their directories. The returned root node has an empty path.
+// Paths that end with a slash, e.g. untracked directories reported by git, are kept as files.
+func Build(paths []string) *Node {
+	root := &Node{Index: -1}
+	for i, path := range paths {
+		components := strings.Split(strings.TrimSuffix(path, separator), separator)
+		dir := root
+		for _, name := range components[:len(components)-1] {
+			dir = dir.childDir(name)
+		}
+		fileName := components[len(components)-1]
+		if strings.HasSuffix(path, separator) {
+			fileName += separator
+		}
+		dir.Children = append(dir.Children, &Node{Name: fileName, Path: path, Index: i})
+	}
+	root.sort()
+	root.compact()
+	return root
+}
+
+func (n *Node) childDir(name string) *Node {
+	for _, child := range n.Children {
+		if child.IsDir() && child.Name == name {
+			return child
+		}
+	}
+	dir := &Node{Name: name, Path: n.Path + name + separator, Index: -1}
+	n.Children = append(n.Children, dir)
+	return dir
+}
+
+// sort orders directories before files, each by name.
+func (n *Node) sort() {
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		lhs, rhs := n.Children[i], n.Children[j]
+		if lhs.IsDir() != rhs.IsDir() {
+			return lhs.IsDir()
+		}
+		return lhs.Name < rhs.Name
+	})
+	for _, child := range n.Children {
+		child.sort()
+	}
+}
+
+// compact merges directories whose only child is a directory.
+func (n *Node) compact() {
+	for _, child := range n.Children {
+		for child.IsDir() && len(child.Children) == 1 && child.Children[0].IsDir() {
+			grandChild := child.Children[0]
+			child.Name += separator + grandChild.Name
+			child.Path = grandChild.Path
+			child.Children = grandChild.Children
+		}
+		child.compact()
+	}
+}
+
+// Entry is a visible node of the tree.
+type Entry struct {
+	*Node
+	// Depth of the node. Children of the root have a depth of 0.
+	Depth int
+	// IsCollapsed is true for directories whose children are hidden.
+	IsCollapsed bool
+}
+
+// Flatten returns the nodes below the root in display order.
+// The children of directories for which isCollapsed returns true are skipped.
+func (n *Node) Flatten(isCollapsed func(path string) bool) []Entry {
+	var entries []Entry
+	n.flatten(0, isCollapsed, &entries)
+	return entries
+}
+
+func (n *Node) flatten(depth int, isCollapsed func(path string) bool, entries *[]Entry) {
+	for _, child := range n.Children {
+		entry := Entry{Node: child, Depth: depth}
+		entry.IsCollapsed = child.IsDir() && isCollapsed != nil && isCollapsed(child.Path)
+		*entries = append(*entries, entry)
+		if child.IsDir() && !entry.IsCollapsed {
+			child.flatten(depth+1, isCollapsed, entries)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package filetree
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func describe(entries []Entry) []string {
+	var lines []string
+	for _, entry := range entries {
+		line := fmt.Sprintf("%s%s", strings.Repeat("  ", entry.Depth), entry.Name)
+		if entry.IsCollapsed {
+			line += " +"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestBuild(t *testing.T) {
+	paths := []string{
+		"README.md",
+		"cmd/app/main.go",
+		"internal/core/git/git.go",
+		"internal/core/git/status.go",
+		"internal/core/ui/list.go",
+		"internal/app/model.go",
+		"new/",
+		"a.txt",
+	}
+
+	got := describe(Build(paths).Flatten(nil))
+	expect := []string{
+		"cmd/app",
+		"  main.go",
+		"internal",
+		"  app",
+		"    model.go",
+		"  core",
+		"    git",
+		"      git.go",
+		"      status.go",
+		"    ui",
+		"      list.go",
+		"README.md",
+		"a.txt",
+		"new/",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Got\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(expect, "\n"))
+	}
+}
+
+func TestBuildPaths(t *testing.T) {
+	root := Build([]string{"a/b/c/x.go", "a/b/y.go", "z/"})
+	entries := root.Flatten(nil)
+
+	expect := []struct {
+		name  string
+		path  string
+		index int
+	}{
+		{name: "a/b", path: "a/b/", index: -1},
+		{name: "c", path: "a/b/c/", index: -1},
+		{name: "x.go", path: "a/b/c/x.go", index: 0},
+		{name: "y.go", path: "a/b/y.go", index: 1},
+		{name: "z/", path: "z/", index: 2},
+	}
+	if len(entries) != len(expect) {
+		t.Fatalf("Got %d entries, expected %d", len(entries), len(expect))
+	}
+	for i, e := range expect {
+		if entries[i].Name != e.name || entries[i].Path != e.path || entries[i].Index != e.index {
+			t.Errorf("Got %+v, expected %+v", *entries[i].Node, e)
+		}
+	}
+
+	if got := entries[0].FileIndices(); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("Got file indices %v", got)
+	}
+	if got := entries[4].FileIndices(); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("Got file indices %v", got)
+	}
+}
+
+func TestFlattenCollapsed(t *testing.T) {
+	root := Build([]string{"a/x.go", "a/b/y.go", "a/b/z.go", "c.go"})
+	isCollapsed := func(path string) bool {
+		return path == "a/b/"
+	}
+
+	got := describe(root.Flatten(isCollapsed))
+	expect := []string{
+		"a",
+		"  b +",
+		"  x.go",
+		"c.go",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Got\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(expect, "\n"))
+	}
+}
+
+func TestBuildEmpty(t *testing.T) {
+	if entries := Build(nil).Flatten(nil); len(entries) != 0 {
+		t.Errorf("Expected no entries, got %v", entries)
+	}
+}
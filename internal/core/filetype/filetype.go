@@ -0,0 +1,53 @@
+// Package filetype describes the content of files, e.g. to summarize binary files in a diff.
+package filetype
+
+import (
+	"bytes"
+	"image"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	// Registers the image formats, whose dimensions are described.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// unknownMIMEType is detected for content without known signature.
+const unknownMIMEType = "application/octet-stream"
+
+// Info describes the content of a file.
+type Info struct {
+	MIMEType string
+	// Width and Height are the dimensions of PNG, JPEG and GIF images.
+	Width, Height int
+}
+
+// HasDimensions reports whether the content is an image with known dimensions.
+func (i Info) HasDimensions() bool {
+	return i.Width > 0 && i.Height > 0
+}
+
+// Describe detects the MIME type of the content and the dimensions of images.
+// The MIME type is derived from the extension of the name, if the content
+// is unknown or missing.
+func Describe(name string, content []byte) Info {
+	var info Info
+	if len(content) > 0 {
+		info.MIMEType, _, _ = strings.Cut(http.DetectContentType(content), ";")
+	}
+	if len(info.MIMEType) == 0 || info.MIMEType == unknownMIMEType {
+		if byExtension, _, _ := strings.Cut(mime.TypeByExtension(path.Ext(name)), ";"); len(byExtension) > 0 {
+			info.MIMEType = byExtension
+		} else if len(content) > 0 {
+			info.MIMEType = unknownMIMEType
+		}
+	}
+
+	if config, _, err := image.DecodeConfig(bytes.NewReader(content)); err == nil {
+		info.Width, info.Height = config.Width, config.Height
+	}
+	return info
+}
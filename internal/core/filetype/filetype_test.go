@@ -0,0 +1,59 @@
+package filetype
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func TestDescribeImages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 12, 7))
+	tests := []struct {
+		name   string
+		encode func(*bytes.Buffer) error
+		expect string
+	}{
+		{"a.png", func(b *bytes.Buffer) error { return png.Encode(b, img) }, "image/png"},
+		{"a.jpg", func(b *bytes.Buffer) error { return jpeg.Encode(b, img, nil) }, "image/jpeg"},
+		{"a.gif", func(b *bytes.Buffer) error { return gif.Encode(b, img, nil) }, "image/gif"},
+	}
+
+	for _, test := range tests {
+		var buffer bytes.Buffer
+		if err := test.encode(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		info := Describe(test.name, buffer.Bytes())
+		if info.MIMEType != test.expect || info.Width != 12 || info.Height != 7 {
+			t.Errorf("Describe(%s) got %+v", test.name, info)
+		}
+		if !info.HasDimensions() {
+			t.Errorf("Describe(%s) has no dimensions", test.name)
+		}
+	}
+}
+
+func TestDescribeOtherContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		expect  string
+	}{
+		{"a.txt", "plain text", "text/plain"},
+		{"a.bin", "\x00\x01\x02", "application/octet-stream"},
+		// The extension is used for unknown or missing content.
+		{"a.pdf", "", "application/pdf"},
+		{"a.wasm", "\x00\x01\x02", "application/wasm"},
+		{"a", "", ""},
+	}
+
+	for _, test := range tests {
+		info := Describe(test.name, []byte(test.content))
+		if info.MIMEType != test.expect || info.HasDimensions() {
+			t.Errorf("Describe(%s, %q) got %+v, expected %s", test.name, test.content, info, test.expect)
+		}
+	}
+}
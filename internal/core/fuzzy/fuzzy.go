@@ -0,0 +1,58 @@
+// Package fuzzy matches text against a pattern, e.g. to filter lists.
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Match reports whether all runes of the pattern occur in the text in the
+// same order, ignoring case. It returns the rune indices of the matched
+// characters in the text. A contiguous occurrence of the pattern is preferred
+// over a scattered one. An empty pattern matches every text.
+func Match(pattern, text string) ([]int, bool) {
+	var (
+		patternRunes = []rune(strings.ToLower(pattern))
+		textRunes    = []rune(text)
+	)
+	if len(patternRunes) == 0 {
+		return nil, true
+	}
+
+	if start := indexOf(patternRunes, textRunes); start >= 0 {
+		indices := make([]int, len(patternRunes))
+		for i := range indices {
+			indices[i] = start + i
+		}
+		return indices, true
+	}
+
+	indices := make([]int, 0, len(patternRunes))
+	for i, r := range textRunes {
+		if unicode.ToLower(r) == patternRunes[len(indices)] {
+			indices = append(indices, i)
+			if len(indices) == len(patternRunes) {
+				return indices, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// indexOf returns the rune index of the first case-insensitive occurrence of
+// the lower case pattern in the text, or -1.
+func indexOf(pattern, text []rune) int {
+	for start := 0; start+len(pattern) <= len(text); start++ {
+		isMatch := true
+		for i, r := range pattern {
+			if unicode.ToLower(text[start+i]) != r {
+				isMatch = false
+				break
+			}
+		}
+		if isMatch {
+			return start
+		}
+	}
+	return -1
+}
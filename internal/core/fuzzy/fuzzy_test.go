@@ -0,0 +1,33 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern       string
+		text          string
+		expectIndices []int
+		expectMatch   bool
+	}{
+		{pattern: "", text: "main.go", expectIndices: nil, expectMatch: true},
+		{pattern: "main", text: "cmd/main.go", expectIndices: []int{4, 5, 6, 7}, expectMatch: true},
+		{pattern: "MAIN", text: "cmd/Main.go", expectIndices: []int{4, 5, 6, 7}, expectMatch: true},
+		{pattern: "cmg", text: "cmd/main.go", expectIndices: []int{0, 1, 9}, expectMatch: true},
+		{pattern: "gm", text: "cmd/main.go", expectIndices: nil, expectMatch: false},
+		{pattern: "main.go!", text: "main.go", expectIndices: nil, expectMatch: false},
+		{pattern: "äb", text: "[M] Ä/b.txt", expectIndices: []int{4, 6}, expectMatch: true},
+	}
+
+	for _, test := range tests {
+		indices, isMatch := Match(test.pattern, test.text)
+		if isMatch != test.expectMatch {
+			t.Errorf("Match(%q, %q) = %t, expected %t", test.pattern, test.text, isMatch, test.expectMatch)
+		}
+		if !reflect.DeepEqual(indices, test.expectIndices) {
+			t.Errorf("Match(%q, %q) indices %v, expected %v", test.pattern, test.text, indices, test.expectIndices)
+		}
+	}
+}
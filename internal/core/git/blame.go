@@ -0,0 +1,165 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minHashLength is the length of a SHA-1 hash. SHA-256 hashes are longer.
+const minHashLength = 40
+
+// BlameCommit is the commit that last changed a line.
+type BlameCommit struct {
+	Hash       string
+	Author     string
+	AuthorTime time.Time
+	Summary    string
+	// Previous is the parent commit, in which the line was blamed.
+	// It is empty for the boundary commit.
+	Previous string
+	// PreviousPath is the path of the file in the Previous commit.
+	PreviousPath string
+	// IsBoundary is true if the commit is the first commit of the history.
+	IsBoundary bool
+}
+
+// IsCommitted is false for lines of the work tree that are not committed yet.
+// Git reports them with a hash of zeros.
+func (c BlameCommit) IsCommitted() bool {
+	return len(strings.Trim(c.Hash, "0")) > 0
+}
+
+// ShortHash returns the abbreviated hash of the commit.
+func (c BlameCommit) ShortHash() string {
+	if len(c.Hash) < 8 {
+		return c.Hash
+	}
+	return c.Hash[:8]
+}
+
+// BlameLine is a line of a file together with the commit that last changed it.
+type BlameLine struct {
+	Commit BlameCommit
+	// Path of the file in Commit.
+	Path string
+	// LineNumber in the blamed revision of the file, starting at 1.
+	LineNumber int
+	// OriginalLineNumber of the line in Commit, starting at 1.
+	OriginalLineNumber int
+	Content            string
+}
+
+// ParentBlameOptions returns the options to blame the file in the parent of the
+// commit that last changed the line. It is false if there is no parent.
+func (l BlameLine) ParentBlameOptions() (BlameOptions, bool) {
+	if len(l.Commit.Previous) == 0 {
+		return BlameOptions{}, false
+	}
+	return BlameOptions{Path: l.Commit.PreviousPath, Revision: l.Commit.Previous}, true
+}
+
+// BlameOptions configures a `git blame`.
+type BlameOptions struct {
+	Path string
+	// Revision to blame. The work tree is blamed if empty.
+	Revision string
+}
+
+// Blame returns the lines of the file, each with the commit that last changed it.
+func Blame(opts BlameOptions) ([]BlameLine, error) {
+	if len(opts.Path) == 0 {
+		return nil, errors.New("Missing path.")
+	}
+
+	args := []string{"blame", "--porcelain"}
+	if len(opts.Revision) > 0 {
+		args = append(args, opts.Revision)
+	}
+	args = append(args, "--", opts.Path)
+
+	out, err := newGitCommand(args...).outputStrict()
+	if err != nil {
+		return nil, err
+	}
+	return readBlameFromPorcelainOutput(out), nil
+}
+
+// readBlameFromPorcelainOutput reads the output of `git blame --porcelain`.
+// Each line starts with a header `<hash> <original line> <final line> [<group size>]`,
+// followed by the commit information on the first occurrence of the commit,
+// and ends with the content prefixed by a tab.
+func readBlameFromPorcelainOutput(out string) []BlameLine {
+	var (
+		lines   []BlameLine
+		commits = make(map[string]BlameCommit)
+		current BlameLine
+		// isInHeader is true between a line header and its content.
+		isInHeader bool
+	)
+
+	for _, line := range strings.Split(out, "\n") {
+		if !isInHeader {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || len(fields[0]) < minHashLength {
+				continue
+			}
+			originalLineNumber, err := strconv.Atoi(fields[1])
+			if err != nil {
+				continue
+			}
+			lineNumber, err := strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+
+			commit, ok := commits[fields[0]]
+			if !ok {
+				commit = BlameCommit{Hash: fields[0]}
+			}
+			// The filename is only given on the first line of a group,
+			// which is the only header that contains the group size.
+			var path string
+			if len(fields) == 3 {
+				path = current.Path
+			}
+			current = BlameLine{Commit: commit, Path: path, LineNumber: lineNumber, OriginalLineNumber: originalLineNumber}
+			isInHeader = true
+			continue
+		}
+
+		if content, ok := strings.CutPrefix(line, "\t"); ok {
+			current.Content = content
+			commits[current.Commit.Hash] = current.Commit
+			lines = append(lines, current)
+			isInHeader = false
+			continue
+		}
+
+		name, value, _ := strings.Cut(line, " ")
+		switch name {
+		case "author":
+			current.Commit.Author = value
+		case "author-time":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Commit.AuthorTime = time.Unix(seconds, 0)
+			}
+		case "summary":
+			current.Commit.Summary = value
+		case "boundary":
+			current.Commit.IsBoundary = true
+		case "previous":
+			current.Commit.Previous, current.Commit.PreviousPath, _ = strings.Cut(value, " ")
+		case "filename":
+			current.Path = value
+		}
+	}
+
+	return lines
+}
+
+// ShowCommit returns the message, changed files and diff of the commit.
+func ShowCommit(hash string) (string, error) {
+	return newGitCommand("show", "--stat", "--patch", "--format=fuller", hash).outputStrict()
+}
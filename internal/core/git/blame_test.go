@@ -0,0 +1,122 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadBlameFromPorcelainOutput(t *testing.T) {
+	const (
+		first  = "1111111111111111111111111111111111111111"
+		second = "2222222222222222222222222222222222222222"
+		zero   = "0000000000000000000000000000000000000000"
+	)
+	out := first + " 1 1 1\n" +
+		"author First Author\n" +
+		"author-mail <first@example.com>\n" +
+		"author-time 1700000000\n" +
+		"author-tz +0000\n" +
+		"summary initial\n" +
+		"boundary\n" +
+		"filename old.txt\n" +
+		"\tfirst line\n" +
+		second + " 1 2 2\n" +
+		"author Second\n" +
+		"author-time 1800000000\n" +
+		"summary change\n" +
+		"previous " + first + " old.txt\n" +
+		"filename new.txt\n" +
+		"\tsecond line\n" +
+		second + " 2 3\n" +
+		"\t\tindented line\n" +
+		first + " 2 4 1\n" +
+		"filename old.txt\n" +
+		"\tfourth line\n" +
+		zero + " 5 5 1\n" +
+		"author Not Committed Yet\n" +
+		"summary Version of new.txt from new.txt\n" +
+		"filename new.txt\n" +
+		"\t\n"
+
+	firstCommit := BlameCommit{Hash: first, Author: "First Author", AuthorTime: time.Unix(1700000000, 0), Summary: "initial", IsBoundary: true}
+	secondCommit := BlameCommit{Hash: second, Author: "Second", AuthorTime: time.Unix(1800000000, 0), Summary: "change", Previous: first, PreviousPath: "old.txt"}
+	expect := []BlameLine{
+		{Commit: firstCommit, Path: "old.txt", LineNumber: 1, OriginalLineNumber: 1, Content: "first line"},
+		{Commit: secondCommit, Path: "new.txt", LineNumber: 2, OriginalLineNumber: 1, Content: "second line"},
+		{Commit: secondCommit, Path: "new.txt", LineNumber: 3, OriginalLineNumber: 2, Content: "\tindented line"},
+		{Commit: firstCommit, Path: "old.txt", LineNumber: 4, OriginalLineNumber: 2, Content: "fourth line"},
+		{Commit: BlameCommit{Hash: zero, Author: "Not Committed Yet", Summary: "Version of new.txt from new.txt"}, Path: "new.txt", LineNumber: 5, OriginalLineNumber: 5},
+	}
+
+	got := readBlameFromPorcelainOutput(out)
+	if len(got) != len(expect) {
+		t.Fatalf("Got %d lines %+v, expected %d", len(got), got, len(expect))
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("Line %d: got %+v, expected %+v", i+1, got[i], expect[i])
+		}
+	}
+
+	if got[4].Commit.IsCommitted() || !got[0].Commit.IsCommitted() {
+		t.Error("Expected only the last line to be not committed")
+	}
+	if _, ok := got[0].ParentBlameOptions(); ok {
+		t.Error("Expected no parent for the boundary commit")
+	}
+	if opts, ok := got[1].ParentBlameOptions(); !ok || opts != (BlameOptions{Path: "old.txt", Revision: first}) {
+		t.Errorf("Got parent options %+v", opts)
+	}
+}
+
+func TestBlameParentFollowsRename(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "old.txt", "one\ntwo\n")
+	runGit(t, repo, "mv", "old.txt", "new.txt")
+	runGit(t, repo, "commit", "-m", "rename")
+	commitFile(t, repo, "new.txt", "one\nreformatted\n")
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("one\nreformatted\nlocal\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	lines, err := Blame(BlameOptions{Path: "new.txt"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("Got %d lines, expected 3", len(lines))
+	}
+	if lines[2].Commit.IsCommitted() {
+		t.Error("Expected local line not to be committed")
+	}
+	if lines[1].Commit.Summary != "update new.txt" {
+		t.Errorf("Got summary '%s', expected 'update new.txt'", lines[1].Commit.Summary)
+	}
+
+	opts, ok := lines[1].ParentBlameOptions()
+	if !ok {
+		t.Fatal("Expected parent")
+	}
+	parentLines, err := Blame(opts)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(parentLines) != 2 || parentLines[1].Content != "two" || parentLines[1].Path != "old.txt" {
+		t.Errorf("Got parent lines %+v", parentLines)
+	}
+
+	if _, err := Blame(BlameOptions{Path: "missing.txt"}); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	commit, err := ShowCommit(lines[1].Commit.Hash)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(commit) == 0 {
+		t.Error("Expected commit details")
+	}
+}
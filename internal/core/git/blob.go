@@ -0,0 +1,56 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AttributeSet, AttributeUnset and AttributeUnspecified are the states of a gitattribute
+// without a value, e.g. of `linguist-generated`, `-diff` and an attribute, which isn't set at all.
+const (
+	AttributeSet         = "set"
+	AttributeUnset       = "unset"
+	AttributeUnspecified = "unspecified"
+)
+
+// BlobSize returns the size in bytes of the object with the hash.
+func BlobSize(hash string) (int64, error) {
+	out, err := newGitCommand("cat-file", "-s", hash).outputStrict()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+}
+
+// BlobHeader returns at most the first n bytes of the blob with the hash,
+// e.g. to detect its type without reading all of it.
+func BlobHeader(hash string, n int) ([]byte, error) {
+	return newGitCommand("cat-file", "blob", hash).outputHead(n)
+}
+
+// Attributes returns the values of the gitattributes with the names for each of the paths,
+// e.g. AttributeSet for `linguist-generated` or AttributeUnset for `-diff`.
+func Attributes(paths []string, names ...string) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+	if len(paths) == 0 || len(names) == 0 {
+		return result, nil
+	}
+
+	input := strings.Join(paths, nulSeparator) + nulSeparator
+	args := append([]string{"check-attr", "--stdin", "-z"}, names...)
+	out, err := newGitCommand(args...).withStdin(input).outputStrict()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each attribute is printed as `<path> NUL <attribute> NUL <value> NUL`.
+	fields := strings.Split(strings.TrimSuffix(out, nulSeparator), nulSeparator)
+	for i := 0; i+2 < len(fields); i += 3 {
+		path, name, value := fields[i], fields[i+1], fields[i+2]
+		if result[path] == nil {
+			result[path] = make(map[string]string)
+		}
+		result[path][name] = value
+	}
+	return result, nil
+}
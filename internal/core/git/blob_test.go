@@ -0,0 +1,64 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBlobSizeAndContent(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "a.bin", "\x00binary")
+	hash := runGit(t, repo, "rev-parse", "HEAD:a.bin")
+	t.Chdir(repo)
+
+	size, err := BlobSize(hash[:7])
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if size != 7 {
+		t.Errorf("Expected size 7, got %d", size)
+	}
+	for _, test := range []struct {
+		n      int
+		expect string
+	}{
+		{n: 3, expect: "\x00bi"},
+		{n: 7, expect: "\x00binary"},
+		{n: 512, expect: "\x00binary"},
+	} {
+		header, err := BlobHeader(hash, test.n)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if string(header) != test.expect {
+			t.Errorf("Expected header %q of %d bytes, got %q", test.expect, test.n, header)
+		}
+	}
+	if _, err := BlobHeader("0000000", 512); err == nil {
+		t.Error("Expected an error for the header of a missing blob")
+	}
+	if _, err := BlobSize("0000000"); err == nil {
+		t.Error("Expected an error for a missing blob")
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, ".gitattributes", "*.lock linguist-generated\n*.svg -diff\n")
+	t.Chdir(repo)
+
+	attributes, err := Attributes([]string{"go.lock", "logo.svg", "main.go", "with space.lock"}, "linguist-generated", "diff")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expect := map[string]map[string]string{
+		"go.lock":  {"linguist-generated": AttributeSet, "diff": AttributeUnspecified},
+		"logo.svg": {"linguist-generated": AttributeUnspecified, "diff": AttributeUnset},
+		"main.go":  {"linguist-generated": AttributeUnspecified, "diff": AttributeUnspecified},
+		// Paths are passed on stdin, so that any number of them and any characters are supported.
+		"with space.lock": {"linguist-generated": AttributeSet, "diff": AttributeUnspecified},
+	}
+	if !reflect.DeepEqual(attributes, expect) {
+		t.Errorf("Expected %v, got %v", expect, attributes)
+	}
+}
@@ -1,9 +1,14 @@
 package git
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strings"
 )
 
 type gitCommand struct {
@@ -30,6 +35,173 @@ func (gc *gitCommand) output() (string, error) {
 	return string(out), nil
 }
 
+// runStrict runs the command and, unlike run, reports a non-zero exit status
+// as an error containing the output git wrote to stderr.
+func (gc *gitCommand) runStrict() error {
+	var stderr bytes.Buffer
+	gc.cmd.Stderr = &stderr
+	if err := gc.cmd.Run(); err != nil {
+		if isExitError(err) {
+			return newCommandError(strings.Split(stderr.String(), "\n"))
+		}
+		return err
+	}
+	return nil
+}
+
+// outputStrict returns the output of the command and, unlike output, reports
+// a non-zero exit status as an error containing the output git wrote to stderr.
+func (gc *gitCommand) outputStrict() (string, error) {
+	var stderr bytes.Buffer
+	gc.cmd.Stderr = &stderr
+	out, err := gc.cmd.Output()
+	if err != nil {
+		if isExitError(err) {
+			return "", newCommandError(strings.Split(stderr.String(), "\n"))
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// outputHead returns at most the first n bytes of the output of the command. The
+// command is stopped once they are read, e.g. to not read all of a large blob.
+func (gc *gitCommand) outputHead(n int) ([]byte, error) {
+	var stderr bytes.Buffer
+	gc.cmd.Stderr = &stderr
+	stdout, err := gc.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := gc.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	head, err := io.ReadAll(io.LimitReader(stdout, int64(n)))
+	if err != nil || len(head) == n {
+		// The rest of the output isn't needed.
+		_ = gc.cmd.Process.Kill()
+		_ = gc.cmd.Wait()
+		return head, err
+	}
+	if err := gc.cmd.Wait(); err != nil {
+		if isExitError(err) {
+			return nil, newCommandError(strings.Split(stderr.String(), "\n"))
+		}
+		return nil, err
+	}
+	return head, nil
+}
+
+// runWithProgress runs the command and reports every line git writes to stderr.
+// Progress updates, which git terminates with a carriage return, are reported
+// as separate lines. A non-zero exit status is reported as an error containing
+// the regular (newline terminated) messages.
+func (gc *gitCommand) runWithProgress(onProgress ProgressHandler) error {
+	stderr, err := gc.cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := gc.cmd.Start(); err != nil {
+		return err
+	}
+
+	var (
+		messages []string
+		scanner  = bufio.NewScanner(stderr)
+	)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		isProgress := strings.HasSuffix(line, "\r")
+		line = strings.TrimRight(line, "\r\n")
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		if !isProgress {
+			messages = append(messages, line)
+		}
+		if onProgress != nil {
+			onProgress(line)
+		}
+	}
+
+	if err := gc.cmd.Wait(); err != nil {
+		if isExitError(err) {
+			return newCommandError(messages)
+		}
+		return err
+	}
+	return nil
+}
+
+// withStdin passes the input to the standard input of the command.
+func (gc *gitCommand) withStdin(input string) *gitCommand {
+	gc.cmd.Stdin = strings.NewReader(input)
+	return gc
+}
+
+// withoutTerminalPrompt prevents git from asking for credentials on the terminal,
+// which is owned by the ui. Configured credential helpers are still used.
+func (gc *gitCommand) withoutTerminalPrompt() *gitCommand {
+	gc.cmd.Env = append(gc.env(), "GIT_TERMINAL_PROMPT=0")
+	return gc
+}
+
+// withoutSSHPrompt runs ssh in batch mode, so that it fails instead of asking
+// for passphrases or host key confirmations. A custom ssh command is kept as is.
+func (gc *gitCommand) withoutSSHPrompt() *gitCommand {
+	if len(os.Getenv("GIT_SSH_COMMAND")) > 0 || len(configValue("core.sshCommand")) > 0 {
+		return gc
+	}
+	gc.cmd.Env = append(gc.env(), "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	return gc
+}
+
+func (gc *gitCommand) env() []string {
+	if gc.cmd.Env == nil {
+		return os.Environ()
+	}
+	return gc.cmd.Env
+}
+
+// scanProgressLines is a bufio.SplitFunc that splits at carriage returns and
+// newlines. The returned tokens keep their terminator.
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// commandError is returned for git commands that exited with a non-zero status.
+type commandError struct {
+	msg string
+}
+
+func newCommandError(lines []string) commandError {
+	var messages []string
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			messages = append(messages, line)
+		}
+	}
+	if len(messages) == 0 {
+		return commandError{msg: "git exited with an error"}
+	}
+	return commandError{msg: strings.Join(messages, "\n")}
+}
+
+func (e commandError) Error() string {
+	return e.msg
+}
+
 func isExitError(err error) bool {
 	var ee *exec.ExitError
 	return errors.As(err, &ee)
@@ -41,6 +213,9 @@ type statusOptions struct {
 	isNULTerminated  bool
 	hasBranch        bool
 	isShort          bool
+	// untrackedFiles is the mode of `--untracked-files`. Git's default if empty.
+	untrackedFiles UntrackedFilesMode
+	showIgnored    bool
 }
 
 func newStatusCmd(opts statusOptions) *gitCommand {
@@ -66,6 +241,14 @@ func newStatusCmd(opts statusOptions) *gitCommand {
 		args = append(args, "-b")
 	}
 
+	if len(opts.untrackedFiles) > 0 {
+		args = append(args, fmt.Sprintf("--untracked-files=%s", opts.untrackedFiles))
+	}
+
+	if opts.showIgnored {
+		args = append(args, "--ignored")
+	}
+
 	return newGitCommand(args...)
 }
 
@@ -74,6 +257,10 @@ type DiffOptions struct {
 	IsStaged         bool
 	IsNameStatusOnly bool
 	IsUntracked      bool
+	// UntrackedPaths are diffed after FilePath, e.g. the untracked files of
+	// a directory, which a plain diff of the directory leaves out.
+	UntrackedPaths []string
+	Format         DiffFormat
 }
 
 var untrackedFileDiffArgs = [3]string{
@@ -93,8 +280,13 @@ func newDiffCmd(opts DiffOptions) *gitCommand {
 		args = append(args, "--name-status")
 	}
 
+	args = append(args, opts.Format.args()...)
+
 	if opts.IsUntracked {
 		args = append(args, untrackedFileDiffArgs[:]...)
+	} else {
+		// Shows the commits of a changed submodule instead of its hashes.
+		args = append(args, "--submodule=log")
 	}
 
 	if len(opts.FilePath) > 0 {
@@ -107,6 +299,6 @@ func newDiffCmd(opts DiffOptions) *gitCommand {
 	return newGitCommand(args...)
 }
 
-func removeFileCmd(filePath string) *exec.Cmd {
-	return exec.Command("rm", "-rf", filePath)
+func removeFileCmd(filePaths ...string) *exec.Cmd {
+	return exec.Command("rm", append([]string{"-rf", "--"}, filePaths...)...)
 }
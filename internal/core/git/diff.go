@@ -0,0 +1,100 @@
+package git
+
+import "fmt"
+
+// DefaultContextLines is git's default number of context lines around changes.
+const DefaultContextLines = 3
+
+// WhitespaceMode controls which whitespace changes are ignored by a diff.
+// The modes are flags, which can be combined like their options of git.
+type WhitespaceMode byte
+
+const (
+	// WhitespaceIgnoreAll ignores all whitespace when comparing lines.
+	WhitespaceIgnoreAll WhitespaceMode = 1 << iota
+	// WhitespaceIgnoreChange ignores changes in the amount of whitespace.
+	WhitespaceIgnoreChange
+	// WhitespaceIgnoreBlankLines ignores changes whose lines are all blank.
+	WhitespaceIgnoreBlankLines
+)
+
+var whitespaceModeArgs = []struct {
+	mode WhitespaceMode
+	arg  string
+}{
+	{WhitespaceIgnoreAll, "--ignore-all-space"},
+	{WhitespaceIgnoreChange, "--ignore-space-change"},
+	{WhitespaceIgnoreBlankLines, "--ignore-blank-lines"},
+}
+
+// Has reports whether all flags of the mode are set.
+func (m WhitespaceMode) Has(mode WhitespaceMode) bool {
+	return m&mode == mode
+}
+
+// Toggle sets the flags of the mode, if they are not set, and clears them otherwise.
+func (m WhitespaceMode) Toggle(mode WhitespaceMode) WhitespaceMode {
+	if m.Has(mode) {
+		return m &^ mode
+	}
+	return m | mode
+}
+
+// DiffAlgorithm is the algorithm to compute a diff with.
+type DiffAlgorithm string
+
+const (
+	// DiffAlgorithmMyers is the basic greedy algorithm, which is git's default.
+	DiffAlgorithmMyers DiffAlgorithm = "myers"
+	// DiffAlgorithmPatience aligns unique lines first, which often keeps blocks of code together.
+	DiffAlgorithmPatience DiffAlgorithm = "patience"
+	// DiffAlgorithmHistogram extends patience to support lines, which are not unique.
+	DiffAlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+// DiffFormat configures how the changes of a diff are computed and shown.
+// The zero value uses git's defaults, which may be configured.
+type DiffFormat struct {
+	// Whitespace are the whitespace changes to ignore. None are ignored, if zero.
+	Whitespace WhitespaceMode
+	// ContextLines is the number of unchanged lines around changes, if IsContextLinesSet.
+	// Otherwise git's default, which can be configured by `diff.context`, is used.
+	ContextLines      int
+	IsContextLinesSet bool
+	// Algorithm is the diff algorithm.
+	// Git's default, which can be configured by `diff.algorithm`, if empty.
+	Algorithm DiffAlgorithm
+	// RenameThreshold is the minimum similarity in percent of a renamed file.
+	// Git's default, if zero.
+	RenameThreshold int
+	// IsFunctionContext shows the whole function around changes as context.
+	IsFunctionContext bool
+}
+
+func (f DiffFormat) args() []string {
+	var args []string
+
+	for _, whitespace := range whitespaceModeArgs {
+		if f.Whitespace.Has(whitespace.mode) {
+			args = append(args, whitespace.arg)
+		}
+	}
+
+	if f.IsContextLinesSet {
+		args = append(args, fmt.Sprintf("--unified=%d", f.ContextLines))
+	}
+
+	if len(f.Algorithm) > 0 {
+		args = append(args, fmt.Sprintf("--diff-algorithm=%s", f.Algorithm))
+	}
+
+	if f.RenameThreshold > 0 {
+		args = append(args, fmt.Sprintf("--find-renames=%d%%", f.RenameThreshold))
+	}
+
+	if f.IsFunctionContext {
+		args = append(args, "--function-context")
+	}
+
+	return args
+}
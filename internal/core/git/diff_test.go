@@ -0,0 +1,97 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffFormatArgs(t *testing.T) {
+	format := DiffFormat{
+		Whitespace:        WhitespaceIgnoreChange | WhitespaceIgnoreBlankLines,
+		ContextLines:      5,
+		IsContextLinesSet: true,
+		Algorithm:         DiffAlgorithmHistogram,
+		RenameThreshold:   70,
+		IsFunctionContext: true,
+	}
+	expect := []string{
+		"--ignore-space-change",
+		"--ignore-blank-lines",
+		"--unified=5",
+		"--diff-algorithm=histogram",
+		"--find-renames=70%",
+		"--function-context",
+	}
+	if args := format.args(); !reflect.DeepEqual(args, expect) {
+		t.Errorf("Expected %q, got %q", expect, args)
+	}
+	if args := (DiffFormat{}).args(); len(args) != 0 {
+		t.Errorf("Expected no args for git's defaults, got %q", args)
+	}
+	if args := (DiffFormat{IsContextLinesSet: true}).args(); !reflect.DeepEqual(args, []string{"--unified=0"}) {
+		t.Errorf("Expected no context lines, got %q", args)
+	}
+}
+
+func TestWhitespaceModeToggle(t *testing.T) {
+	mode := WhitespaceMode(0).Toggle(WhitespaceIgnoreAll).Toggle(WhitespaceIgnoreBlankLines)
+	if !mode.Has(WhitespaceIgnoreAll) || !mode.Has(WhitespaceIgnoreBlankLines) || mode.Has(WhitespaceIgnoreChange) {
+		t.Errorf("Expected -w and --ignore-blank-lines to be set independently, got %b", mode)
+	}
+	if mode = mode.Toggle(WhitespaceIgnoreAll); mode != WhitespaceIgnoreBlankLines {
+		t.Errorf("Expected only --ignore-blank-lines to be left, got %b", mode)
+	}
+}
+
+func TestDiffWithFormat(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "a.txt", "1\n2\n3\n4\n5\n6\nx\n")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("1\n2\n3\n4\n5\n6\n  x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+
+	diff, err := Diff(DiffOptions{FilePath: "a.txt", Format: DiffFormat{ContextLines: 1, IsContextLinesSet: true}})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !strings.Contains(diff, "@@ -6,2 +6,2 @@") {
+		t.Errorf("Expected one line of context, got:\n%s", diff)
+	}
+
+	diff, err = Diff(DiffOptions{FilePath: "a.txt", Format: DiffFormat{Whitespace: WhitespaceIgnoreAll}})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if strings.Contains(diff, "@@") {
+		t.Errorf("Expected whitespace changes to be ignored, got:\n%s", diff)
+	}
+}
+
+func TestDiffWithUntrackedPaths(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	if err := os.Mkdir(filepath.Join(repo, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, repo, "dir/a.txt", "a\n")
+	if err := os.WriteFile(filepath.Join(repo, "dir", "a.txt"), []byte("b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "dir", "new.txt"), []byte("new\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+
+	diff, err := Diff(DiffOptions{FilePath: "dir/", UntrackedPaths: []string{"dir/new.txt"}})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	for _, expect := range []string{"+++ b/dir/a.txt", "+++ b/dir/new.txt", "+new"} {
+		if !strings.Contains(diff, expect) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", expect, diff)
+		}
+	}
+}
@@ -10,8 +10,8 @@ import (
 
 // Status retrieves the current `git status` represented
 // by WorkTreeStatus object.
-func Status() (WorkTreeStatus, error) {
-	return loadWorkTreeStatus()
+func Status(opts StatusOptions) (WorkTreeStatus, error) {
+	return loadWorkTreeStatus(opts)
 }
 
 // StageFile stages a file at the given path.
@@ -30,6 +30,22 @@ func UnstageFile(path string) error {
 	return newGitCommand("restore", "--staged", path).run()
 }
 
+// StageFiles stages the files at the given paths.
+func StageFiles(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return newGitCommand(append([]string{"add", "--"}, paths...)...).run()
+}
+
+// UnstageFiles unstages the files at the given paths.
+func UnstageFiles(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return newGitCommand(append([]string{"restore", "--staged", "--"}, paths...)...).run()
+}
+
 // ResetFile either resets the file to the index or deletes it in case
 // it is untracked.
 func ResetFile(filePath string, isUntracked bool) error {
@@ -42,14 +58,48 @@ func ResetFile(filePath string, isUntracked bool) error {
 	return newGitCommand("restore", filePath).run()
 }
 
+// ResetFiles resets the tracked files to the index and deletes the untracked files.
+func ResetFiles(files FileStatusList) error {
+	var trackedPaths, untrackedPaths []string
+	for _, file := range files {
+		if file.IsUntracked() {
+			untrackedPaths = append(untrackedPaths, file.Path)
+		} else {
+			trackedPaths = append(trackedPaths, file.Path)
+		}
+	}
+
+	if len(untrackedPaths) > 0 {
+		if err := removeFileCmd(untrackedPaths...).Run(); err != nil && !isExitError(err) {
+			return err
+		}
+	}
+	if len(trackedPaths) == 0 {
+		return nil
+	}
+	return newGitCommand(append([]string{"restore", "--"}, trackedPaths...)...).run()
+}
+
 // UnstageAll unstages all staged files in the work tree.
 func UnstageAll() error {
 	return UnstageFile(".")
 }
 
 // Diff performs a `git diff“ with the given options.
+// The diffs of the untracked paths are appended to it.
 func Diff(opt DiffOptions) (string, error) {
-	return newDiffCmd(opt).output()
+	diff, err := newDiffCmd(opt).output()
+	if err != nil {
+		return diff, err
+	}
+	for _, path := range opt.UntrackedPaths {
+		untrackedDiff, err := newDiffCmd(DiffOptions{FilePath: path, IsUntracked: true, Format: opt.Format}).output()
+		if err != nil {
+			return diff, err
+		}
+		diff += untrackedDiff
+	}
+	return diff, nil
 }
 
 // Commit performs a commit with the given message.
@@ -57,9 +107,14 @@ func Commit(msg string) error {
 	return newGitCommand("commit", "-m", msg).run()
 }
 
-// CurrentBranch returns the name of the current current branch or an error.
+// CurrentBranch returns the name of the current branch or an error.
+// It is empty in detached HEAD state.
 func CurrentBranch() (string, error) {
-	return newGitCommand("branch", "--show--current").output()
+	out, err := newGitCommand("branch", "--show-current").output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
 }
 
 // CoreEditorValue returns the currently set local editor for git
@@ -74,23 +129,50 @@ func CoreGlobalEditorValue() (string, error) {
 	return newGitCommand("config", "--global", "core.editor").output()
 }
 
-// RootFolder returns the git root folder.
+// BackgroundFetchIntervalValue returns the configured interval of gitglance's
+// background fetch, e.g. `5m`.
+func BackgroundFetchIntervalValue() (string, error) {
+	return newGitCommand("config", "gitglance.fetchInterval").output()
+}
+
+// DiffMaxBytesValue returns the configured size in bytes of the largest diffs,
+// which gitglance shows without asking, e.g. `1048576` for `1m`.
+func DiffMaxBytesValue() (string, error) {
+	return newGitCommand("config", "--type=int", "gitglance.diffMaxBytes").output()
+}
+
+// DiffMaxLinesValue returns the configured number of lines of the largest diffs,
+// which gitglance shows without asking.
+func DiffMaxLinesValue() (string, error) {
+	return newGitCommand("config", "--type=int", "gitglance.diffMaxLines").output()
+}
+
+// RootFolder returns the absolute path of the root folder of the current work tree.
 func RootFolder() (string, error) {
-	output, err := newGitCommand("rev-parse", "--git-dir").output()
+	out, err := newGitCommand("rev-parse", "--show-toplevel").output()
 	if err != nil {
 		return "", err
 	}
-	output = strings.ReplaceAll(output, "\n", "")
-	return strings.TrimSpace(output), nil
+	return strings.TrimSpace(out), nil
 }
 
-// MergeMsg returns the content of the file .git/MERGE_MSG
+// GitPath returns the path of a file inside of the git directory, e.g. `MERGE_MSG`.
+// Unlike `--git-dir`, it resolves files shared by all work trees to the common
+// git directory and per work tree files to the git directory of the linked work tree.
+func GitPath(name string) (string, error) {
+	out, err := newGitCommand("rev-parse", "--path-format=absolute", "--git-path", name).output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// MergeMsg returns the content of the file MERGE_MSG of the current work tree.
 func MergeMsg() (string, error) {
-	folder, err := RootFolder()
+	mergeMsgPath, err := GitPath("MERGE_MSG")
 	if err != nil {
 		return "", err
 	}
-	mergeMsgPath := fmt.Sprintf("%s/MERGE_MSG", folder)
 	mergeFile, err := os.ReadFile(mergeMsgPath)
 	if err != nil {
 		return "", err
@@ -108,15 +190,23 @@ func IsInWorkTree() bool {
 }
 
 type CreateStashOpts struct {
+	// WithUntracked also stashes untracked files.
 	WithUntracked bool
-	WithAll       bool
-	Message       string
+	// WithAll also stashes untracked and ignored files.
+	WithAll bool
+	// KeepIndex leaves the staged changes in the index and the work tree.
+	KeepIndex bool
+	// StagedOnly only stashes the staged changes.
+	StagedOnly bool
+	// Paths limits the stash to the given files. Stashes all files if empty.
+	Paths   []string
+	Message string
 }
 
 func CreateStash(opts CreateStashOpts) error {
 	// Since there is no way to filter the git stash output for errors only,
 	// we try to determine it manually based on the current work tree.
-	status, err := loadWorkTreeStatus()
+	status, err := loadWorkTreeStatus(StatusOptions{})
 	if err != nil {
 		return err
 	}
@@ -125,7 +215,7 @@ func CreateStash(opts CreateStashOpts) error {
 		return errors.New("No local changes to save")
 	}
 
-	args := []string{"stash"}
+	args := []string{"stash", "push"}
 
 	if opts.WithUntracked {
 		args = append(args, "-u")
@@ -135,20 +225,79 @@ func CreateStash(opts CreateStashOpts) error {
 		args = append(args, "-a")
 	}
 
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+
+	if opts.StagedOnly {
+		args = append(args, "--staged")
+	}
+
 	if len(opts.Message) > 0 {
-		args = append(args, "-m", fmt.Sprintf("\"%s\"", opts.Message))
+		args = append(args, "-m", opts.Message)
+	}
+
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
 	}
 
-	return newGitCommand(args...).run()
+	return newGitCommand(args...).runStrict()
 }
 
 func GetStash() (Stash, error) {
-	out, err := newGitCommand("stash", "list").output()
+	out, err := newGitCommand("stash", "list", fmt.Sprintf("--format=%s", stashFormat)).output()
 	if err != nil {
 		return Stash([]StashEntry{}), err
 	}
-	return newDefaultStashBuilder().
-		makeStashFromMultilineText(out)
+	return newDefaultStashBuilder().makeStashFromOutput(out), nil
+}
+
+// StashEntryFiles returns the files changed in the stash entry, including
+// untracked files. The change of each file is reported as UnstagedStatusCode.
+func StashEntryFiles(entry StashEntry) (FileStatusList, error) {
+	out, err := newGitCommand(
+		"stash", "show", "--name-status", "-z", "--include-untracked", entry.Ref(),
+	).output()
+	if err != nil {
+		return nil, err
+	}
+	files := readNameStatusFromOutput(out)
+
+	untrackedOut, err := newGitCommand(
+		"stash", "show", "--name-status", "-z", "--only-untracked", entry.Ref(),
+	).output()
+	if err != nil {
+		return nil, err
+	}
+	untrackedPaths := map[string]bool{}
+	for _, file := range readNameStatusFromOutput(untrackedOut) {
+		untrackedPaths[file.Path] = true
+	}
+
+	for i, file := range files {
+		if untrackedPaths[file.Path] {
+			files[i].UnstagedStatusCode = Untracked
+		}
+	}
+	return files, nil
+}
+
+// StashEntryDiff returns the diff of a single file of the stash entry.
+// Untracked files are stored in the third parent of the stash commit.
+func StashEntryDiff(entry StashEntry, file FileStatus) (string, error) {
+	if file.IsUntracked() {
+		return newGitCommand(
+			"show", "--format=", fmt.Sprintf("%s^3", entry.Ref()), "--", file.Path,
+		).output()
+	}
+
+	args := []string{"diff", fmt.Sprintf("%s^1", entry.Ref()), entry.Ref(), "--"}
+	if len(file.Extra) > 0 {
+		args = append(args, file.Extra)
+	}
+	args = append(args, file.Path)
+	return newGitCommand(args...).output()
 }
 
 func ApplyStashEntry(entry StashEntry) error {
@@ -159,6 +308,11 @@ func ApplyStashIndex(index int) error {
 	return newGitCommand("stash", "apply", fmt.Sprintf("%d", index)).run()
 }
 
+// ApplyStashEntryWithIndex applies the entry and restores which changes were staged.
+func ApplyStashEntryWithIndex(entry StashEntry) error {
+	return newGitCommand("stash", "apply", "--index", entry.Ref()).runStrict()
+}
+
 func PopStashEntry(entry StashEntry) error {
 	return PopStashIndex(entry.Index())
 }
@@ -174,3 +328,46 @@ func DropStashEntry(entry StashEntry) error {
 func DropStashIndex(index int) error {
 	return newGitCommand("stash", "drop", fmt.Sprintf("%d", index)).run()
 }
+
+// BranchFromStashEntry creates and checks out a new branch at the commit the entry was
+// created on, applies the entry and drops it on success.
+func BranchFromStashEntry(entry StashEntry, name string) error {
+	if len(name) == 0 {
+		return errors.New("Missing branch name.")
+	}
+	return newGitCommand("stash", "branch", name, entry.Ref()).runStrict()
+}
+
+// RenameStashEntry replaces the message of the entry.
+// The renamed entry is stored as the latest entry, before the old one is dropped.
+func RenameStashEntry(entry StashEntry, message string) error {
+	if len(message) == 0 {
+		return errors.New("Missing message.")
+	}
+
+	out, err := newGitCommand("rev-parse", entry.Ref()).output()
+	if err != nil {
+		return err
+	}
+	hash := strings.TrimSpace(out)
+	if len(hash) == 0 {
+		return fmt.Errorf("Stash entry %s not found.", entry.Ref())
+	}
+
+	// Keep the format git uses, so that the branch can still be read.
+	if len(entry.Branch()) > 0 {
+		message = fmt.Sprintf("On %s%s%s", entry.Branch(), stashEntryComponentSeparator, message)
+	}
+	if err := newGitCommand("stash", "store", "-m", message, hash).runStrict(); err != nil {
+		return err
+	}
+
+	// Storing moved the old entry down by one.
+	movedEntry := StashEntry{idx: entry.Index() + 1}
+	return newGitCommand("stash", "drop", movedEntry.Ref()).runStrict()
+}
+
+// ClearStash removes all stash entries.
+func ClearStash() error {
+	return newGitCommand("stash", "clear").runStrict()
+}
@@ -0,0 +1,121 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyFormat is the `git log` format to read the history of a file.
+// Commits start with a record separator, fields are separated by NUL.
+// The name status of the file follows the format.
+const historyFormat = "%x1e%H%x00%an%x00%at%x00%s"
+
+const recordSeparator = "\x1e"
+
+// FileCommit is a commit that changed a file.
+type FileCommit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	// Status is the change of the file in the commit.
+	Status StatusCode
+	// Path of the file after the commit.
+	Path string
+	// OldPath of the file before the commit, if it was renamed or copied.
+	OldPath string
+}
+
+// ShortHash returns the abbreviated hash of the commit.
+func (c FileCommit) ShortHash() string {
+	if len(c.Hash) < 8 {
+		return c.Hash
+	}
+	return c.Hash[:8]
+}
+
+func (c FileCommit) IsRenamed() bool {
+	return c.Status == Renamed
+}
+
+// FileHistory returns the commits that changed the file, newest first.
+// Renames are followed, so older commits may refer to a different path.
+func FileHistory(path string) ([]FileCommit, error) {
+	if len(path) == 0 {
+		return nil, errors.New("Missing path.")
+	}
+
+	out, err := newGitCommand(
+		"log",
+		"--follow",
+		"--name-status",
+		"-z",
+		fmt.Sprintf("--format=%s", historyFormat),
+		"--",
+		path,
+	).outputStrict()
+	if err != nil {
+		return nil, err
+	}
+	return readFileHistoryFromOutput(out), nil
+}
+
+func readFileHistoryFromOutput(out string) []FileCommit {
+	var commits []FileCommit
+	for _, record := range strings.Split(out, recordSeparator) {
+		if commit, err := readFileCommitFromOutputRecord(record); err == nil {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// readFileCommitFromOutputRecord reads a single commit, e.g.
+// `<hash>\x00<author>\x00<time>\x00<subject>\x00\nR100\x00<old>\x00<new>\x00`.
+func readFileCommitFromOutputRecord(record string) (FileCommit, error) {
+	fields := strings.Split(record, nulSeparator)
+	if len(fields) < 4 || len(fields[0]) == 0 {
+		return FileCommit{}, errors.New("Can't read commit. Unexpected number of fields.")
+	}
+
+	commit := FileCommit{
+		Hash:    fields[0],
+		Author:  fields[1],
+		Subject: fields[3],
+	}
+	if seconds, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+		commit.Date = time.Unix(seconds, 0)
+	}
+
+	// Commits without changes to the file, e.g. merges, have no name status.
+	nameStatus := strings.Join(fields[4:], nulSeparator)
+	nameStatus = strings.TrimLeft(nameStatus, "\n")
+	if files := readNameStatusFromOutput(nameStatus); len(files) > 0 {
+		commit.Status = files[0].UnstagedStatusCode
+		commit.Path = files[0].Path
+		commit.OldPath = files[0].Extra
+	}
+	return commit, nil
+}
+
+// FileCommitDiff returns the changes of the commit to the file.
+func FileCommitDiff(commit FileCommit) (string, error) {
+	args := []string{"show", "--format=", "-M", commit.Hash, "--"}
+	if len(commit.OldPath) > 0 {
+		args = append(args, commit.OldPath)
+	}
+	return newGitCommand(append(args, commit.Path)...).outputStrict()
+}
+
+// DiffWorkTreeWithFileCommit returns the changes of the file in the work tree at
+// path since the commit. The paths are paired, if the file was renamed since.
+func DiffWorkTreeWithFileCommit(commit FileCommit, path string) (string, error) {
+	args := []string{"diff", "-M", commit.Hash, "--", path}
+	if commit.Path != path {
+		args = append(args, commit.Path)
+	}
+	return newGitCommand(args...).outputStrict()
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadFileHistoryFromOutput(t *testing.T) {
+	out := strings.Join([]string{
+		recordSeparator + "aaa", "Alice", "1700000000", "rename", "\nR100", "old.txt", "new.txt", "",
+		recordSeparator + "bbb", "Bob", "1600000000", "subject: with colon", "\nM", "old.txt", "",
+		recordSeparator + "ccc", "Merger", "invalid", "merge", "",
+		recordSeparator + "broken",
+	}, nulSeparator)
+
+	expect := []FileCommit{
+		{Hash: "aaa", Author: "Alice", Date: time.Unix(1700000000, 0), Subject: "rename", Status: Renamed, Path: "new.txt", OldPath: "old.txt"},
+		{Hash: "bbb", Author: "Bob", Date: time.Unix(1600000000, 0), Subject: "subject: with colon", Status: Modified, Path: "old.txt"},
+		{Hash: "ccc", Author: "Merger", Subject: "merge"},
+	}
+
+	got := readFileHistoryFromOutput(out)
+	if len(got) != len(expect) {
+		t.Fatalf("Got %+v, expected %+v", got, expect)
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("Got %+v, expected %+v", got[i], expect[i])
+		}
+	}
+}
+
+func TestFileHistory(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "old.txt", "one\n")
+	commitFile(t, repo, "old.txt", "one\ntwo\n")
+	runGit(t, repo, "mv", "old.txt", "new.txt")
+	runGit(t, repo, "commit", "-m", "rename")
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("one\ntwo\nlocal\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	history, err := FileHistory("new.txt")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Got %d commits %+v, expected 3", len(history), history)
+	}
+	if c := history[0]; !c.IsRenamed() || c.OldPath != "old.txt" || c.Path != "new.txt" {
+		t.Errorf("Expected rename, got %+v", c)
+	}
+	if c := history[2]; c.Status != Added || c.Path != "old.txt" {
+		t.Errorf("Expected old.txt to be added, got %+v", c)
+	}
+
+	diff, err := FileCommitDiff(history[1])
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !strings.Contains(diff, "+two") || strings.Contains(diff, "README") {
+		t.Errorf("Unexpected diff:\n%s", diff)
+	}
+
+	diff, err = DiffWorkTreeWithFileCommit(history[1], "new.txt")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !strings.Contains(diff, "rename from old.txt") || !strings.Contains(diff, "+local") {
+		t.Errorf("Unexpected work tree diff:\n%s", diff)
+	}
+}
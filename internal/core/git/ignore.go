@@ -0,0 +1,246 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IgnoreFile is a file that contains patterns of files git should ignore.
+type IgnoreFile byte
+
+const (
+	// RootIgnoreFile is the .gitignore at the root of the work tree.
+	RootIgnoreFile IgnoreFile = iota
+	// NestedIgnoreFile is the .gitignore in the directory of the ignored path.
+	NestedIgnoreFile
+	// ExcludeIgnoreFile is .git/info/exclude, which is not shared with others.
+	ExcludeIgnoreFile
+)
+
+// IgnorePatternType describes which files of a path a pattern ignores.
+type IgnorePatternType byte
+
+const (
+	// PathIgnorePattern ignores exactly the path.
+	PathIgnorePattern IgnorePatternType = iota
+	// DirectoryIgnorePattern ignores the directory of the path.
+	DirectoryIgnorePattern
+	// ExtensionIgnorePattern ignores all files with the extension of the path.
+	ExtensionIgnorePattern
+)
+
+// ignoreFileDir returns the directory of the ignore file relative to the root of
+// the work tree. Patterns of the file are relative to it. Empty for the root.
+func ignoreFileDir(file IgnoreFile, filePath string) string {
+	if file != NestedIgnoreFile {
+		return ""
+	}
+	dir := path.Dir(strings.TrimSuffix(filePath, "/"))
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// IgnoreFilePath returns the path of the ignore file relative to the root of the work tree,
+// e.g. to show it to the user. The nested ignore file depends on the path to ignore.
+func IgnoreFilePath(file IgnoreFile, filePath string) string {
+	if file == ExcludeIgnoreFile {
+		return path.Join(".git", "info", "exclude")
+	}
+	return path.Join(ignoreFileDir(file, filePath), ".gitignore")
+}
+
+// IgnorePattern returns the pattern of the given type to ignore the path in the ignore file.
+// Paths of untracked directories are expected to end with a slash.
+func IgnorePattern(patternType IgnorePatternType, file IgnoreFile, filePath string) (string, error) {
+	var (
+		isDir   = strings.HasSuffix(filePath, "/")
+		relPath = strings.TrimSuffix(filePath, "/")
+	)
+	if dir := ignoreFileDir(file, filePath); len(dir) > 0 {
+		relPath = strings.TrimPrefix(relPath, dir+"/")
+	}
+
+	switch patternType {
+	case PathIgnorePattern:
+		if isDir {
+			return "/" + relPath + "/", nil
+		}
+		return "/" + relPath, nil
+	case DirectoryIgnorePattern:
+		dir := path.Dir(relPath)
+		if dir == "." {
+			return "", errors.New("The path has no parent directory to ignore.")
+		}
+		return "/" + dir + "/", nil
+	case ExtensionIgnorePattern:
+		ext := path.Ext(relPath)
+		if isDir || len(ext) == 0 || ext == relPath {
+			return "", errors.New("The path has no extension to ignore.")
+		}
+		return "*" + ext, nil
+	}
+	return "", errors.New("Unknown pattern type.")
+}
+
+// AddIgnorePattern appends the pattern to the ignore file for the path.
+// The file is created if it doesn't exist. Existing patterns are not added again.
+func AddIgnorePattern(pattern string, file IgnoreFile, filePath string) error {
+	return AddIgnorePatterns([]string{pattern}, file, filePath)
+}
+
+// AddIgnorePatterns appends the patterns to the ignore file for the path at once.
+// The file is created if it doesn't exist. Existing patterns are not added again.
+func AddIgnorePatterns(patterns []string, file IgnoreFile, filePath string) error {
+	var trimmedPatterns []string
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); len(pattern) > 0 {
+			trimmedPatterns = append(trimmedPatterns, pattern)
+		}
+	}
+	if len(trimmedPatterns) == 0 {
+		return errors.New("Missing pattern.")
+	}
+
+	var (
+		ignorePath string
+		err        error
+	)
+	if file == ExcludeIgnoreFile {
+		ignorePath, err = GitPath("info/exclude")
+	} else {
+		var root string
+		root, err = RootFolder()
+		ignorePath = filepath.Join(root, filepath.FromSlash(IgnoreFilePath(file, filePath)))
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(ignorePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	existingPatterns := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existingPatterns[strings.TrimSpace(line)] = true
+	}
+
+	var newPatterns []string
+	for _, pattern := range trimmedPatterns {
+		if !existingPatterns[pattern] {
+			existingPatterns[pattern] = true
+			newPatterns = append(newPatterns, pattern)
+		}
+	}
+	if len(newPatterns) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ignorePath), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(ignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lines := strings.Join(newPatterns, "\n") + "\n"
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		lines = "\n" + lines
+	}
+	_, err = f.WriteString(lines)
+	return err
+}
+
+// TrackedPaths returns the paths, which are tracked or, for directories ending with a slash,
+// contain a tracked file. Ignore patterns have no effect on tracked files.
+func TrackedPaths(paths []string) ([]string, error) {
+	args := append([]string{"ls-files", "-z", "--"}, paths...)
+	out, err := newGitCommand(args...).outputStrict()
+	if err != nil {
+		return nil, err
+	}
+	return readTrackedPaths(out, paths), nil
+}
+
+// readTrackedPaths returns the paths, which match any of the files of the output of `git ls-files -z`.
+func readTrackedPaths(out string, paths []string) []string {
+	if len(out) == 0 {
+		return nil
+	}
+
+	var (
+		files        = strings.Split(strings.TrimSuffix(out, nulSeparator), nulSeparator)
+		trackedPaths []string
+	)
+	for _, path := range paths {
+		isTracked := slices.ContainsFunc(files, func(file string) bool {
+			return file == path || strings.HasSuffix(path, "/") && strings.HasPrefix(file, path)
+		})
+		if isTracked {
+			trackedPaths = append(trackedPaths, path)
+		}
+	}
+	return trackedPaths
+}
+
+// IgnoreRule is a pattern of an ignore file.
+type IgnoreRule struct {
+	// Source is the ignore file of the pattern.
+	Source     string
+	LineNumber int
+	Pattern    string
+}
+
+func (r IgnoreRule) IsEmpty() bool {
+	return len(r.Pattern) == 0
+}
+
+// String describes the rule like git, e.g. `.gitignore:3:*.log`.
+func (r IgnoreRule) String() string {
+	if r.IsEmpty() {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%s", r.Source, r.LineNumber, r.Pattern)
+}
+
+// CheckIgnore returns the rules that ignore the paths, by path.
+// Paths that aren't ignored are not included.
+func CheckIgnore(paths []string) (map[string]IgnoreRule, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	input := strings.Join(paths, nulSeparator) + nulSeparator
+	// check-ignore exits with 1 if none of the paths is ignored.
+	out, err := newGitCommand("check-ignore", "--verbose", "-z", "--stdin").withStdin(input).output()
+	if err != nil {
+		return nil, err
+	}
+	return readIgnoreRulesFromOutput(out), nil
+}
+
+// readIgnoreRulesFromOutput reads the output of `git check-ignore --verbose -z`,
+// which reports `<source>NUL<line number>NUL<pattern>NUL<path>NUL` for each path.
+func readIgnoreRulesFromOutput(out string) map[string]IgnoreRule {
+	var (
+		rules  = make(map[string]IgnoreRule)
+		fields = strings.Split(out, nulSeparator)
+	)
+	for i := 0; i+3 < len(fields); i += 4 {
+		lineNumber, err := strconv.Atoi(fields[i+1])
+		if err != nil || len(fields[i+2]) == 0 {
+			continue
+		}
+		rules[fields[i+3]] = IgnoreRule{Source: fields[i], LineNumber: lineNumber, Pattern: fields[i+2]}
+	}
+	return rules
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIgnorePattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		patternType IgnorePatternType
+		file        IgnoreFile
+		path        string
+		expect      string
+		expectErr   bool
+	}{
+		{name: "path at root", patternType: PathIgnorePattern, file: RootIgnoreFile, path: "a.log", expect: "/a.log"},
+		{name: "nested path", patternType: PathIgnorePattern, file: RootIgnoreFile, path: "dir/sub/a.log", expect: "/dir/sub/a.log"},
+		{name: "nested path in nested file", patternType: PathIgnorePattern, file: NestedIgnoreFile, path: "dir/sub/a.log", expect: "/a.log"},
+		{name: "directory path", patternType: PathIgnorePattern, file: ExcludeIgnoreFile, path: "dir/build/", expect: "/dir/build/"},
+		{name: "directory path in nested file", patternType: PathIgnorePattern, file: NestedIgnoreFile, path: "dir/build/", expect: "/build/"},
+		{name: "directory", patternType: DirectoryIgnorePattern, file: RootIgnoreFile, path: "dir/sub/a.log", expect: "/dir/sub/"},
+		{name: "directory of directory", patternType: DirectoryIgnorePattern, file: RootIgnoreFile, path: "dir/build/", expect: "/dir/"},
+		{name: "no directory at root", patternType: DirectoryIgnorePattern, file: RootIgnoreFile, path: "a.log", expectErr: true},
+		{name: "no directory in nested file", patternType: DirectoryIgnorePattern, file: NestedIgnoreFile, path: "dir/a.log", expectErr: true},
+		{name: "extension", patternType: ExtensionIgnorePattern, file: NestedIgnoreFile, path: "dir/a.tar.gz", expect: "*.gz"},
+		{name: "no extension", patternType: ExtensionIgnorePattern, file: RootIgnoreFile, path: "dir/Makefile", expectErr: true},
+		{name: "dot file", patternType: ExtensionIgnorePattern, file: RootIgnoreFile, path: ".env", expectErr: true},
+		{name: "no extension of directory", patternType: ExtensionIgnorePattern, file: RootIgnoreFile, path: "dir.d/", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := IgnorePattern(test.patternType, test.file, test.path)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got '%s'", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			if got != test.expect {
+				t.Errorf("Got '%s', expected '%s'", got, test.expect)
+			}
+		})
+	}
+}
+
+func TestIgnoreFilePath(t *testing.T) {
+	if got := IgnoreFilePath(RootIgnoreFile, "dir/a.log"); got != ".gitignore" {
+		t.Errorf("Got '%s'", got)
+	}
+	if got := IgnoreFilePath(NestedIgnoreFile, "dir/build/"); got != "dir/.gitignore" {
+		t.Errorf("Got '%s'", got)
+	}
+	if got := IgnoreFilePath(ExcludeIgnoreFile, "dir/a.log"); got != ".git/info/exclude" {
+		t.Errorf("Got '%s'", got)
+	}
+}
+
+func TestAddIgnorePattern(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	if err := os.MkdirAll(filepath.Join(repo, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.log", "dir/b.log", "dir/c.tmp", "d.txt"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Without trailing newline, which must be kept intact.
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("*.bak"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	if err := AddIgnorePattern("/a.log", RootIgnoreFile, "a.log"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := AddIgnorePattern("/a.log", RootIgnoreFile, "a.log"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := AddIgnorePattern("*.tmp", NestedIgnoreFile, "dir/c.tmp"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := AddIgnorePattern("/dir/b.log", ExcludeIgnoreFile, "dir/b.log"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(repo, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "*.bak\n/a.log\n" {
+		t.Errorf("Got .gitignore '%s'", content)
+	}
+
+	untracked := runGit(t, repo, "ls-files", "--others", "--exclude-standard")
+	if got := strings.Fields(untracked); strings.Join(got, " ") != ".gitignore d.txt dir/.gitignore" {
+		t.Errorf("Got untracked files %v", got)
+	}
+}
+
+func TestAddIgnorePatterns(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("/a.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	patterns := []string{"/a.log", " /b.log ", "", "*.tmp", "/b.log"}
+	if err := AddIgnorePatterns(patterns, RootIgnoreFile, "b.log"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(repo, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "/a.log\n/b.log\n*.tmp\n" {
+		t.Errorf("Got .gitignore '%s'", content)
+	}
+
+	if err := AddIgnorePatterns([]string{" "}, RootIgnoreFile, "b.log"); err == nil {
+		t.Error("Expected an error for missing patterns")
+	}
+}
+
+func TestTrackedPaths(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	for _, dir := range []string{"dir", "other"} {
+		if err := os.Mkdir(filepath.Join(repo, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commitFile(t, repo, "tracked.txt", "tracked")
+	commitFile(t, repo, "dir/tracked.txt", "tracked")
+	for _, path := range []string{"untracked.txt", "other/untracked.txt"} {
+		if err := os.WriteFile(filepath.Join(repo, path), []byte("untracked"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(repo)
+	got, err := TrackedPaths([]string{"tracked.txt", "untracked.txt", "dir/", "other/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []string{"tracked.txt", "dir/"}; !slices.Equal(got, expect) {
+		t.Errorf("Expected tracked paths %v, got %v", expect, got)
+	}
+}
+
+func TestReadIgnoreRulesFromOutput(t *testing.T) {
+	out := strings.Join([]string{
+		".gitignore", "2", "*.log", "debug.log",
+		"sub/.gitignore", "1", "!keep", "sub/keep",
+		"", "", "", "not-ignored",
+		"",
+	}, nulSeparator)
+
+	got := readIgnoreRulesFromOutput(out)
+	expect := map[string]IgnoreRule{
+		"debug.log": {Source: ".gitignore", LineNumber: 2, Pattern: "*.log"},
+		"sub/keep":  {Source: "sub/.gitignore", LineNumber: 1, Pattern: "!keep"},
+	}
+	if len(got) != len(expect) {
+		t.Fatalf("Got %+v, expected %+v", got, expect)
+	}
+	for path, rule := range expect {
+		if got[path] != rule {
+			t.Errorf("Got %+v for %s, expected %+v", got[path], path, rule)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProgressHandler receives the output of long running git commands line by line.
+type ProgressHandler func(line string)
+
+// FetchOptions configures a `git fetch`.
+type FetchOptions struct {
+	// Remote to fetch from. If empty, git decides based on the current branch.
+	Remote string
+	// All fetches all remotes and ignores Remote.
+	All bool
+	// Prune removes remote-tracking references that no longer exist on the remote.
+	Prune bool
+	// IsBackground fetches quietly without updating FETCH_HEAD. It never asks
+	// for credentials, which includes ssh passphrases and host key confirmations.
+	IsBackground bool
+}
+
+// Fetch performs a `git fetch` with the given options.
+// Progress is only requested from git if onProgress is set.
+func Fetch(opts FetchOptions, onProgress ProgressHandler) error {
+	args := []string{"fetch"}
+
+	if onProgress != nil {
+		args = append(args, "--progress")
+	}
+
+	if opts.IsBackground {
+		args = append(args, "--quiet", "--no-write-fetch-head")
+	}
+
+	if opts.All {
+		args = append(args, "--all")
+	}
+
+	if opts.Prune {
+		args = append(args, "--prune")
+	}
+
+	if !opts.All && len(opts.Remote) > 0 {
+		args = append(args, opts.Remote)
+	}
+
+	cmd := newGitCommand(args...).withoutTerminalPrompt()
+	if opts.IsBackground {
+		cmd = cmd.withoutSSHPrompt()
+	}
+	return cmd.runWithProgress(onProgress)
+}
+
+// PullMode decides how a pull integrates the upstream changes.
+type PullMode byte
+
+const (
+	// PullModeDefault merges or rebases as configured by branch.<name>.rebase
+	// or pull.rebase. Without configuration it merges.
+	PullModeDefault PullMode = iota
+	PullModeMerge
+	PullModeRebase
+)
+
+func (m PullMode) String() string {
+	switch m {
+	case PullModeMerge:
+		return "merge"
+	case PullModeRebase:
+		return "rebase"
+	}
+	return "default"
+}
+
+// PullOptions configures a `git pull`.
+type PullOptions struct {
+	Mode PullMode
+}
+
+// Pull performs a `git pull` with the given options.
+func Pull(opts PullOptions, onProgress ProgressHandler) error {
+	args := []string{"pull", "--progress", pullRebaseArg(opts.Mode)}
+	return newGitCommand(args...).
+		withoutTerminalPrompt().
+		runWithProgress(onProgress)
+}
+
+// ConfiguredPullMode returns PullModeRebase if the current branch is configured to
+// rebase on pull, otherwise PullModeMerge.
+func ConfiguredPullMode() PullMode {
+	if pullRebaseArg(PullModeDefault) == "--no-rebase" {
+		return PullModeMerge
+	}
+	return PullModeRebase
+}
+
+func pullRebaseArg(mode PullMode) string {
+	switch mode {
+	case PullModeMerge:
+		return "--no-rebase"
+	case PullModeRebase:
+		return "--rebase"
+	}
+
+	value := pullRebaseConfigValue()
+	switch value {
+	case "true", "interactive", "i":
+		// An interactive rebase can't be hosted by the ui.
+		return "--rebase"
+	case "merges", "m":
+		return "--rebase=merges"
+	}
+	return "--no-rebase"
+}
+
+func pullRebaseConfigValue() string {
+	if branch, err := CurrentBranch(); err == nil && len(branch) > 0 {
+		if value := configValue(fmt.Sprintf("branch.%s.rebase", branch)); len(value) > 0 {
+			return value
+		}
+	}
+	return configValue("pull.rebase")
+}
+
+// PushOptions configures a `git push`.
+type PushOptions struct {
+	// Remote to push to. If empty, git decides based on the current branch.
+	Remote string
+	// Branch to push. Only used together with Remote.
+	Branch string
+	// SetUpstream configures Remote as upstream for Branch.
+	SetUpstream bool
+	// ForceWithLease overwrites the remote branch, unless it changed
+	// since it was last fetched.
+	ForceWithLease bool
+}
+
+// Push performs a `git push` with the given options.
+func Push(opts PushOptions, onProgress ProgressHandler) error {
+	args := []string{"push", "--progress"}
+
+	if opts.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+
+	if len(opts.Remote) > 0 {
+		args = append(args, opts.Remote)
+		if len(opts.Branch) > 0 {
+			args = append(args, opts.Branch)
+		}
+	}
+
+	return newGitCommand(args...).
+		withoutTerminalPrompt().
+		runWithProgress(onProgress)
+}
+
+// CurrentBranchPushOptions returns the options to push the current branch.
+// If the branch has no upstream yet, the options set it on the push remote.
+func CurrentBranchPushOptions() (PushOptions, error) {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return PushOptions{}, err
+	}
+	if len(branch) == 0 {
+		return PushOptions{}, errors.New("Not on a branch. Can't push a detached HEAD.")
+	}
+
+	if upstream, err := Upstream(); err != nil {
+		return PushOptions{}, err
+	} else if len(upstream) > 0 {
+		return PushOptions{}, nil
+	}
+
+	remote, err := pushRemote(branch)
+	if err != nil {
+		return PushOptions{}, err
+	}
+	return PushOptions{Remote: remote, Branch: branch, SetUpstream: true}, nil
+}
+
+// Upstream returns the upstream of the current branch, e.g. `origin/main`.
+// It is empty if no upstream is configured.
+func Upstream() (string, error) {
+	out, err := newGitCommand("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}").output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// UpstreamRemote returns the remote of the current branch's upstream.
+// It is empty if no upstream is configured.
+func UpstreamRemote() (string, error) {
+	branch, err := CurrentBranch()
+	if err != nil || len(branch) == 0 {
+		return "", err
+	}
+	return configValue(fmt.Sprintf("branch.%s.remote", branch)), nil
+}
+
+// Remotes returns the names of all configured remotes.
+func Remotes() ([]string, error) {
+	out, err := newGitCommand("remote").output()
+	if err != nil {
+		return nil, err
+	}
+	var remotes []string
+	for _, remote := range strings.Split(out, "\n") {
+		if remote = strings.TrimSpace(remote); len(remote) > 0 {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes, nil
+}
+
+// pushRemote returns the remote to push the branch to.
+// The branch may be empty in detached HEAD state.
+func pushRemote(branch string) (string, error) {
+	if len(branch) > 0 {
+		if remote := configValue(fmt.Sprintf("branch.%s.pushRemote", branch)); len(remote) > 0 {
+			return remote, nil
+		}
+	}
+	if remote := configValue("remote.pushDefault"); len(remote) > 0 {
+		return remote, nil
+	}
+
+	remotes, err := Remotes()
+	if err != nil {
+		return "", err
+	}
+	for _, remote := range remotes {
+		if remote == "origin" {
+			return remote, nil
+		}
+	}
+	if len(remotes) == 1 {
+		return remotes[0], nil
+	}
+	if len(remotes) == 0 {
+		return "", errors.New("No remote configured.")
+	}
+	return "", errors.New("Multiple remotes configured. Please set remote.pushDefault.")
+}
+
+// configValue returns the value of a git config key or an empty string if not set.
+func configValue(key string) string {
+	out, err := newGitCommand("config", "--get", key).output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,198 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchAllWithPrune(t *testing.T) {
+	bare, clone := newRemoteSetup(t)
+	other := newClone(t, bare, "other")
+
+	runGit(t, other, "push", "origin", "main:obsolete")
+	runGit(t, clone, "fetch")
+	commitFile(t, other, "file.txt", "other")
+	runGit(t, other, "push", "origin", "main", ":obsolete")
+
+	t.Chdir(clone)
+	var lines []string
+	err := Fetch(FetchOptions{All: true, Prune: true}, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(lines) == 0 {
+		t.Error("Expected progress output")
+	}
+	if got, expect := runGit(t, clone, "rev-parse", "origin/main"), runGit(t, other, "rev-parse", "HEAD"); got != expect {
+		t.Errorf("origin/main not fetched. Got '%s', expected '%s'", got, expect)
+	}
+	if refs := runGit(t, clone, "branch", "-r"); strings.Contains(refs, "obsolete") {
+		t.Errorf("Expected origin/obsolete to be pruned, got:\n%s", refs)
+	}
+}
+
+func TestBackgroundFetchOfUpstreamRemote(t *testing.T) {
+	bare, clone := newRemoteSetup(t)
+	other := newClone(t, bare, "other")
+	commitFile(t, other, "file.txt", "other")
+	runGit(t, other, "push")
+
+	t.Chdir(clone)
+	remote, err := UpstreamRemote()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if remote != "origin" {
+		t.Fatalf("Got upstream remote '%s', expected 'origin'", remote)
+	}
+
+	if err := Fetch(FetchOptions{Remote: remote, IsBackground: true}, nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(clone, ".git", "FETCH_HEAD")); !os.IsNotExist(err) {
+		t.Error("Expected FETCH_HEAD not to be written")
+	}
+
+	status, err := Status(StatusOptions{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if status.Upstream != "origin/main" || status.Behind != 1 || status.Ahead != 0 {
+		t.Errorf("Got upstream '%s' ahead %d behind %d", status.Upstream, status.Ahead, status.Behind)
+	}
+}
+
+func TestFetchError(t *testing.T) {
+	_, clone := newRemoteSetup(t)
+
+	t.Chdir(clone)
+	err := Fetch(FetchOptions{Remote: "missing"}, nil)
+	if err == nil {
+		t.Error("Expected error for missing remote")
+	}
+}
+
+func TestPull(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          PullMode
+		pullRebase    string
+		expectParents int
+	}{
+		{"merge", PullModeMerge, "", 2},
+		{"rebase", PullModeRebase, "", 1},
+		{"default without config", PullModeDefault, "", 2},
+		{"default with pull.rebase", PullModeDefault, "true", 1},
+		{"merge overrides pull.rebase", PullModeMerge, "true", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bare, clone := newRemoteSetup(t)
+			other := newClone(t, bare, "other")
+			commitFile(t, other, "other.txt", "other")
+			runGit(t, other, "push")
+			commitFile(t, clone, "local.txt", "local")
+			if len(tt.pullRebase) > 0 {
+				runGit(t, clone, "config", "pull.rebase", tt.pullRebase)
+			}
+
+			t.Chdir(clone)
+			if err := Pull(PullOptions{Mode: tt.mode}, nil); err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			parents := strings.Fields(runGit(t, clone, "rev-list", "--parents", "-n", "1", "HEAD"))[1:]
+			if len(parents) != tt.expectParents {
+				t.Errorf("Got %d parents for HEAD, expected %d", len(parents), tt.expectParents)
+			}
+		})
+	}
+}
+
+func TestConfiguredPullMode(t *testing.T) {
+	_, clone := newRemoteSetup(t)
+	t.Chdir(clone)
+
+	if mode := ConfiguredPullMode(); mode != PullModeMerge {
+		t.Errorf("Got '%s', expected '%s'", mode, PullModeMerge)
+	}
+
+	runGit(t, clone, "config", "pull.rebase", "merges")
+	if mode := ConfiguredPullMode(); mode != PullModeRebase {
+		t.Errorf("Got '%s', expected '%s'", mode, PullModeRebase)
+	}
+
+	runGit(t, clone, "config", "branch.main.rebase", "false")
+	if mode := ConfiguredPullMode(); mode != PullModeMerge {
+		t.Errorf("Branch config not honoured. Got '%s', expected '%s'", mode, PullModeMerge)
+	}
+}
+
+func TestPushSetsUpstreamOnFirstPush(t *testing.T) {
+	_, clone := newRemoteSetup(t)
+	runGit(t, clone, "switch", "-c", "feature")
+	commitFile(t, clone, "feature.txt", "feature")
+
+	t.Chdir(clone)
+	opts, err := CurrentBranchPushOptions()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expect := PushOptions{Remote: "origin", Branch: "feature", SetUpstream: true}
+	if opts != expect {
+		t.Fatalf("Got %+v, expected %+v", opts, expect)
+	}
+
+	if err := Push(opts, nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if upstream, _ := Upstream(); upstream != "origin/feature" {
+		t.Errorf("Got upstream '%s', expected 'origin/feature'", upstream)
+	}
+
+	opts, err = CurrentBranchPushOptions()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if opts != (PushOptions{}) {
+		t.Errorf("Expected default options once upstream is set, got %+v", opts)
+	}
+}
+
+func TestPushForceWithLease(t *testing.T) {
+	bare, clone := newRemoteSetup(t)
+	runGit(t, clone, "commit", "--amend", "-m", "rewritten")
+
+	t.Chdir(clone)
+	if err := Push(PushOptions{}, nil); err == nil {
+		t.Fatal("Expected non fast-forward push to fail")
+	}
+	if err := Push(PushOptions{ForceWithLease: true}, nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if got, expect := runGit(t, bare, "rev-parse", "main"), runGit(t, clone, "rev-parse", "HEAD"); got != expect {
+		t.Errorf("Remote not updated. Got '%s', expected '%s'", got, expect)
+	}
+}
+
+func TestPushForceWithLeaseRejectsUnknownChanges(t *testing.T) {
+	bare, clone := newRemoteSetup(t)
+	other := newClone(t, bare, "other")
+	commitFile(t, other, "other.txt", "other")
+	runGit(t, other, "push")
+	runGit(t, clone, "commit", "--amend", "-m", "rewritten")
+
+	t.Chdir(clone)
+	if err := Push(PushOptions{ForceWithLease: true}, nil); err == nil {
+		t.Error("Expected lease to reject unfetched remote changes")
+	}
+}
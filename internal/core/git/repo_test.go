@@ -0,0 +1,67 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateGitEnv makes sure that the tests are not affected by
+// the global or system git configuration of the machine.
+func isolateGitEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "gitglance")
+	t.Setenv("GIT_AUTHOR_EMAIL", "gitglance@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "gitglance")
+	t.Setenv("GIT_COMMITTER_EMAIL", "gitglance@example.com")
+}
+
+// runGit runs git inside of dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile writes content to name and commits it.
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", "update "+name)
+}
+
+// newRemoteSetup creates a bare repository with one commit on main and returns its
+// path together with a clone that tracks it.
+func newRemoteSetup(t *testing.T) (bare string, clone string) {
+	t.Helper()
+	isolateGitEnv(t)
+
+	root := t.TempDir()
+	bare = filepath.Join(root, "origin.git")
+	runGit(t, root, "init", "--bare", "-b", "main", bare)
+
+	clone = newClone(t, bare, "initial")
+	commitFile(t, clone, "README.md", "initial")
+	runGit(t, clone, "push", "-u", "origin", "main")
+	return bare, clone
+}
+
+// newClone clones the bare repository next to it.
+func newClone(t *testing.T, bare string, name string) string {
+	t.Helper()
+	dir := filepath.Join(filepath.Dir(bare), name)
+	runGit(t, filepath.Dir(bare), "clone", "-q", bare, dir)
+	return dir
+}
@@ -2,11 +2,17 @@ package git
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// stashFormat is the `git stash list` format to read stash entries.
+// Fields are separated by NUL, entries by newline.
+const stashFormat = "%gd%x00%h%x00%P%x00%ct%x00%gs"
+
 const (
 	stashEntryIdxRegexPattern    = `stash@{([0-9]+)}`
 	stashEntryComponentSeparator = ": "
@@ -28,33 +34,49 @@ func newDefaultStashBuilder() stashBuilder {
 	}
 }
 
-func (b stashBuilder) makeStashFromMultilineText(text string) (Stash, error) {
-	lines := strings.Split(text, "\n")
-	return b.makeStashFromLines(lines...)
+// makeStashFromOutput reads the output of `git stash list` using stashFormat.
+// Lines that can't be read are skipped.
+func (b stashBuilder) makeStashFromOutput(out string) Stash {
+	var entries []StashEntry
+	for _, line := range strings.Split(out, "\n") {
+		if entry, err := b.makeStashEntryFromOutputLine(line); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
 }
 
-func (b stashBuilder) makeStashFromLines(lines ...string) (Stash, error) {
-	var (
-		entries []StashEntry
-		err     error
-	)
-
-	for _, line := range lines {
-		idx, idxErr := b.getStashEntryIdxFromLine(line)
-		if idxErr != nil {
-			err = idxErr
-			break
-		}
-		msg, msgErr := b.getStashEntryMsgFromLine(line)
-		if msgErr != nil {
-			err = msgErr
-			break
-		}
+func (b stashBuilder) makeStashEntryFromOutputLine(line string) (StashEntry, error) {
+	fields := strings.Split(line, nulSeparator)
+	if len(fields) != 5 {
+		return StashEntry{}, errors.New("Can't read stash entry. Unexpected number of fields.")
+	}
 
-		entries = append(entries, StashEntry{idx: idx, msg: msg})
+	idx, err := b.getStashEntryIdxFromLine(fields[0])
+	if err != nil {
+		return StashEntry{}, err
+	}
+
+	entry := StashEntry{
+		idx:  idx,
+		hash: fields[1],
+		// Untracked files are stored in a third parent commit.
+		hasUntracked: len(strings.Fields(fields[2])) > 2,
+		msg:          fields[4],
 	}
 
-	return entries, err
+	if seconds, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+		entry.date = time.Unix(seconds, 0)
+	}
+
+	// The subject is either `WIP on <branch>: <commit>` or `On <branch>: <message>`.
+	if msg, err := b.getStashEntryMsgFromLine(fields[4]); err == nil {
+		prefix := strings.TrimSuffix(fields[4], stashEntryComponentSeparator+msg)
+		entry.branch = strings.TrimPrefix(strings.TrimPrefix(prefix, "WIP on "), "On ")
+		entry.msg = msg
+	}
+
+	return entry, nil
 }
 
 func (b stashBuilder) getStashEntryIdxFromLine(line string) (int, error) {
@@ -79,8 +101,12 @@ func (b stashBuilder) getStashEntryMsgFromLine(line string) (string, error) {
 }
 
 type StashEntry struct {
-	idx int
-	msg string
+	idx          int
+	msg          string
+	hash         string
+	branch       string
+	date         time.Time
+	hasUntracked bool
 }
 
 func (s StashEntry) Message() string {
@@ -91,4 +117,30 @@ func (s StashEntry) Index() int {
 	return s.idx
 }
 
+// Hash returns the abbreviated hash of the stash commit.
+func (s StashEntry) Hash() string {
+	return s.hash
+}
+
+// Branch returns the branch the entry was created on.
+// It is `(no branch)` for entries created on a detached HEAD.
+func (s StashEntry) Branch() string {
+	return s.branch
+}
+
+// Date returns when the entry was created.
+func (s StashEntry) Date() time.Time {
+	return s.date
+}
+
+// HasUntracked returns whether the entry contains untracked files.
+func (s StashEntry) HasUntracked() bool {
+	return s.hasUntracked
+}
+
+// Ref returns the reference of the entry, e.g. `stash@{0}`.
+func (s StashEntry) Ref() string {
+	return fmt.Sprintf("stash@{%d}", s.idx)
+}
+
 type Stash []StashEntry
@@ -2,7 +2,11 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetStashIdx(t *testing.T) {
@@ -61,3 +65,304 @@ func TestGetStashEntryMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeStashFromOutput(t *testing.T) {
+	out := strings.Join([]string{
+		strings.Join([]string{"stash@{0}", "1a2b3c4", "aaa bbb ccc", "1700000000", "On main: message: with colon"}, nulSeparator),
+		strings.Join([]string{"stash@{1}", "5d6e7f8", "aaa bbb", "1600000000", "WIP on feature/x: 9abcdef subject"}, nulSeparator),
+		"unexpected line",
+		strings.Join([]string{"stash@{}", "5d6e7f8", "aaa bbb", "1600000000", "On main: no index"}, nulSeparator),
+		strings.Join([]string{"stash@{2}", "0a0b0c0", "aaa bbb", "invalid", "WIP on (no branch): 9abcdef detached"}, nulSeparator),
+		"",
+	}, "\n")
+
+	expect := Stash{
+		{idx: 0, hash: "1a2b3c4", branch: "main", msg: "message: with colon", date: time.Unix(1700000000, 0), hasUntracked: true},
+		{idx: 1, hash: "5d6e7f8", branch: "feature/x", msg: "9abcdef subject", date: time.Unix(1600000000, 0)},
+		{idx: 2, hash: "0a0b0c0", branch: "(no branch)", msg: "9abcdef detached"},
+	}
+
+	got := newDefaultStashBuilder().makeStashFromOutput(out)
+	if len(got) != len(expect) {
+		t.Fatalf("Got %d entries %+v, expected %d", len(got), got, len(expect))
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("Got %+v, expected %+v", got[i], expect[i])
+		}
+	}
+}
+
+func TestGetStash(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	runGit(t, repo, "switch", "-c", "feature")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "stash", "push", "-m", "tracked: only")
+	if err := os.WriteFile(filepath.Join(repo, "untracked.txt"), []byte("untracked"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "stash", "push", "--include-untracked")
+
+	t.Chdir(repo)
+	stash, err := GetStash()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(stash) != 2 {
+		t.Fatalf("Got %d entries, expected 2", len(stash))
+	}
+
+	for i, tt := range []struct {
+		msg          string
+		hasUntracked bool
+	}{
+		{runGit(t, repo, "log", "-1", "--format=%h %s", "HEAD"), true},
+		{"tracked: only", false},
+	} {
+		entry := stash[i]
+		if entry.Index() != i || entry.Message() != tt.msg || entry.Branch() != "feature" || entry.HasUntracked() != tt.hasUntracked {
+			t.Errorf("Got %+v, expected index %d, message '%s' and untracked %t on feature", entry, i, tt.msg, tt.hasUntracked)
+		}
+		if expectHash := runGit(t, repo, "rev-parse", "--short", entry.Ref()); entry.Hash() != expectHash {
+			t.Errorf("Got hash '%s', expected '%s'", entry.Hash(), expectHash)
+		}
+		if entry.Date().IsZero() {
+			t.Error("Expected date to be set")
+		}
+	}
+}
+
+func TestStashEntryFilesAndDiff(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "tracked.txt", "tracked")
+	if err := os.WriteFile(filepath.Join(repo, "tracked.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "untracked.txt"), []byte("untracked"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "stash", "push", "--include-untracked")
+
+	t.Chdir(repo)
+	entry := StashEntry{idx: 0}
+	files, err := StashEntryFiles(entry)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expect := FileStatusList{
+		{Path: "tracked.txt", UnstagedStatusCode: Modified, StagedStatusCode: Unmodified},
+		{Path: "untracked.txt", UnstagedStatusCode: Untracked, StagedStatusCode: Unmodified},
+	}
+	if len(files) != len(expect) {
+		t.Fatalf("Got files %+v, expected %+v", files, expect)
+	}
+	for i := range expect {
+		if files[i] != expect[i] {
+			t.Errorf("Got %+v, expected %+v", files[i], expect[i])
+		}
+	}
+
+	for _, tt := range []struct {
+		file   FileStatus
+		expect string
+	}{
+		{files[0], "+changed"},
+		{files[1], "+untracked"},
+	} {
+		diff, err := StashEntryDiff(entry, tt.file)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if !strings.Contains(diff, tt.expect) {
+			t.Errorf("Diff of %s does not contain '%s':\n%s", tt.file.Path, tt.expect, diff)
+		}
+	}
+}
+
+func TestCreateStash(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            CreateStashOpts
+		expectStashed   []string
+		expectRemaining []string
+		hasError        bool
+	}{
+		{
+			name:            "tracked changes only",
+			opts:            CreateStashOpts{Message: "tracked"},
+			expectStashed:   []string{"staged.txt", "unstaged.txt"},
+			expectRemaining: []string{"untracked.txt"},
+		},
+		{
+			name:            "with untracked",
+			opts:            CreateStashOpts{WithUntracked: true},
+			expectStashed:   []string{"staged.txt", "unstaged.txt", "untracked.txt"},
+			expectRemaining: nil,
+		},
+		{
+			name:            "with ignored",
+			opts:            CreateStashOpts{WithAll: true},
+			expectStashed:   []string{"ignored.log", "staged.txt", "unstaged.txt", "untracked.txt"},
+			expectRemaining: nil,
+		},
+		{
+			name:            "staged only",
+			opts:            CreateStashOpts{StagedOnly: true},
+			expectStashed:   []string{"staged.txt"},
+			expectRemaining: []string{"unstaged.txt", "untracked.txt"},
+		},
+		{
+			name:            "keep index",
+			opts:            CreateStashOpts{KeepIndex: true},
+			expectStashed:   []string{"staged.txt", "unstaged.txt"},
+			expectRemaining: []string{"staged.txt", "untracked.txt"},
+		},
+		{
+			// The stash always records the full index, which is why staged.txt
+			// is part of it. It is left untouched in the work tree though.
+			name:            "selected paths",
+			opts:            CreateStashOpts{WithUntracked: true, Paths: []string{"unstaged.txt", "untracked.txt"}},
+			expectStashed:   []string{"staged.txt", "unstaged.txt", "untracked.txt"},
+			expectRemaining: []string{"staged.txt"},
+		},
+		{
+			name:     "staged only with untracked",
+			opts:     CreateStashOpts{StagedOnly: true, WithUntracked: true},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, repo := newRemoteSetup(t)
+			commitFile(t, repo, ".gitignore", "*.log")
+			commitFile(t, repo, "staged.txt", "staged")
+			commitFile(t, repo, "unstaged.txt", "unstaged")
+			for name, content := range map[string]string{
+				"staged.txt":    "staged changed",
+				"unstaged.txt":  "unstaged changed",
+				"untracked.txt": "untracked",
+				"ignored.log":   "ignored",
+			} {
+				if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			runGit(t, repo, "add", "staged.txt")
+
+			t.Chdir(repo)
+			err := CreateStash(tt.opts)
+			if tt.hasError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			if len(tt.opts.Message) > 0 {
+				if subject := runGit(t, repo, "log", "-1", "--format=%s", "stash@{0}"); !strings.HasSuffix(subject, ": "+tt.opts.Message) {
+					t.Errorf("Got stash subject '%s', expected message '%s'", subject, tt.opts.Message)
+				}
+			}
+
+			files, err := StashEntryFiles(StashEntry{idx: 0})
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			var stashed []string
+			for _, file := range files {
+				stashed = append(stashed, file.Path)
+			}
+			if strings.Join(stashed, ",") != strings.Join(tt.expectStashed, ",") {
+				t.Errorf("Got stashed files %v, expected %v", stashed, tt.expectStashed)
+			}
+
+			var remaining []string
+			for _, line := range strings.Split(runGit(t, repo, "status", "--porcelain"), "\n") {
+				if fields := strings.Fields(line); len(fields) == 2 {
+					remaining = append(remaining, fields[1])
+				}
+			}
+			if strings.Join(remaining, ",") != strings.Join(tt.expectRemaining, ",") {
+				t.Errorf("Got remaining changes %v, expected %v", remaining, tt.expectRemaining)
+			}
+		})
+	}
+}
+
+func TestStashEntryOperations(t *testing.T) {
+	newRepoWithStash := func(t *testing.T) string {
+		_, repo := newRemoteSetup(t)
+		commitFile(t, repo, "staged.txt", "staged")
+		for i, name := range []string{"first", "second"} {
+			if err := os.WriteFile(filepath.Join(repo, "staged.txt"), []byte(name), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if i == 1 {
+				runGit(t, repo, "add", "staged.txt")
+			}
+			runGit(t, repo, "stash", "push", "-m", name)
+		}
+		t.Chdir(repo)
+		return repo
+	}
+
+	t.Run("apply with index", func(t *testing.T) {
+		repo := newRepoWithStash(t)
+		if err := ApplyStashEntryWithIndex(StashEntry{idx: 0}); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if status := runGit(t, repo, "status", "--porcelain"); status != "M  staged.txt" {
+			t.Errorf("Expected staged change to be restored, got '%s'", status)
+		}
+	})
+
+	t.Run("branch", func(t *testing.T) {
+		repo := newRepoWithStash(t)
+		if err := BranchFromStashEntry(StashEntry{idx: 1}, ""); err == nil {
+			t.Error("Expected error for missing branch name")
+		}
+		if err := BranchFromStashEntry(StashEntry{idx: 1}, "from-stash"); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if branch := runGit(t, repo, "branch", "--show-current"); branch != "from-stash" {
+			t.Errorf("Got branch '%s', expected 'from-stash'", branch)
+		}
+		if content, _ := os.ReadFile(filepath.Join(repo, "staged.txt")); string(content) != "first" {
+			t.Errorf("Got content '%s', expected 'first'", content)
+		}
+		if stash := runGit(t, repo, "stash", "list", "--format=%gs"); stash != "On main: second" {
+			t.Errorf("Expected entry to be dropped, got '%s'", stash)
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		repo := newRepoWithStash(t)
+		hash := runGit(t, repo, "rev-parse", "stash@{1}")
+		if err := RenameStashEntry(StashEntry{idx: 1, branch: "main"}, "renamed"); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if stash := runGit(t, repo, "stash", "list", "--format=%gs"); stash != "On main: renamed\nOn main: second" {
+			t.Errorf("Got stash:\n%s", stash)
+		}
+		if got := runGit(t, repo, "rev-parse", "stash@{0}"); got != hash {
+			t.Errorf("Got commit '%s', expected '%s'", got, hash)
+		}
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		repo := newRepoWithStash(t)
+		if err := ClearStash(); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if stash := runGit(t, repo, "stash", "list"); len(stash) > 0 {
+			t.Errorf("Expected empty stash, got:\n%s", stash)
+		}
+	})
+}
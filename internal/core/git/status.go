@@ -1,20 +1,53 @@
 package git
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+// branchTrackingRegex matches the tracking information of the branch header,
+// e.g. `[ahead 1, behind 2]` or `[gone]`.
+var branchTrackingRegex = regexp.MustCompile(`\[(?:gone|(?:ahead (\d+))?(?:, )?(?:behind (\d+))?)\]$`)
+
 // WorkTreeStatus represents the current status of the git work tree.
 type WorkTreeStatus struct {
 	// The branch at the time of creation.
 	// Can be empty if not requested.
 	Branch            string
 	CleanedBranchName string
+	// The upstream of the branch, e.g. `origin/main`. Empty if there is none.
+	Upstream string
+	// Number of commits the branch is ahead of and behind its upstream.
+	Ahead, Behind int
+	// HasSubmodules is true if the repository configures submodules.
+	HasSubmodules bool
 	// List of staged and unstaged files
 	FileStatusList
 }
 
-func loadWorkTreeStatus() (WorkTreeStatus, error) {
+// UntrackedFilesMode controls how untracked files are reported.
+type UntrackedFilesMode string
+
+const (
+	// UntrackedFilesNo doesn't report untracked files.
+	UntrackedFilesNo UntrackedFilesMode = "no"
+	// UntrackedFilesNormal reports untracked directories instead of their files.
+	UntrackedFilesNormal UntrackedFilesMode = "normal"
+	// UntrackedFilesAll reports all untracked files, also inside of untracked directories.
+	UntrackedFilesAll UntrackedFilesMode = "all"
+)
+
+// StatusOptions configure which files are reported by Status.
+type StatusOptions struct {
+	// UntrackedFiles is the mode to report untracked files with.
+	// Git's default, which can be configured by `status.showUntrackedFiles`, if empty.
+	UntrackedFiles UntrackedFilesMode
+	// ShowIgnored reports ignored files together with the rule that ignores them.
+	ShowIgnored bool
+}
+
+func loadWorkTreeStatus(opts StatusOptions) (WorkTreeStatus, error) {
 	if !IsInWorkTree() {
 		return WorkTreeStatus{}, statusError{msg: "Error: Could not read git status. Please run gitglance inside a git repository."}
 	}
@@ -24,22 +57,71 @@ func loadWorkTreeStatus() (WorkTreeStatus, error) {
 		isNULTerminated:  false,
 		hasBranch:        true,
 		isShort:          true,
+		untrackedFiles:   opts.UntrackedFiles,
+		showIgnored:      opts.ShowIgnored,
 	}).output()
 
 	if err != nil {
 		return WorkTreeStatus{}, err
 	}
 
-	return readWorkTreeStatusFromOutput(out)
+	status, err := readWorkTreeStatusFromOutput(out)
+	if err != nil {
+		return status, err
+	}
+
+	if opts.ShowIgnored {
+		if status, err = addIgnoreRules(status); err != nil {
+			return status, err
+		}
+	}
+	return addSubmoduleStatus(status)
+}
+
+// addIgnoreRules adds the matching ignore rule to the ignored files of the status.
+func addIgnoreRules(status WorkTreeStatus) (WorkTreeStatus, error) {
+	var paths []string
+	for _, file := range status.IgnoredFiles() {
+		paths = append(paths, file.Path)
+	}
+	rules, err := CheckIgnore(paths)
+	if err != nil {
+		return status, err
+	}
+	for i, file := range status.FileStatusList {
+		if rule, ok := rules[file.Path]; ok {
+			status.FileStatusList[i].IgnoreRule = rule
+		}
+	}
+	return status, nil
+}
+
+// addSubmoduleStatus adds the submodule state to the changed submodules of the status.
+func addSubmoduleStatus(status WorkTreeStatus) (WorkTreeStatus, error) {
+	paths, err := submodulePaths()
+	if err != nil || len(paths) == 0 {
+		return status, err
+	}
+	status.HasSubmodules = true
+
+	states, err := loadSubmoduleStatus(paths)
+	if err != nil {
+		return status, err
+	}
+	for i, file := range status.FileStatusList {
+		if state, ok := states[file.Path]; ok {
+			status.FileStatusList[i].Submodule = state
+		}
+	}
+	return status, nil
 }
 
 func readWorkTreeStatusFromOutput(statusString string) (WorkTreeStatus, error) {
 	var (
-		components    = strings.Split(statusString, "\n")
-		branch        string
-		cleanedBranch string
-		files         FileStatusList
-		startIdx      = 0
+		components = strings.Split(statusString, "\n")
+		status     WorkTreeStatus
+		files      FileStatusList
+		startIdx   = 0
 	)
 
 	var firstComponent string
@@ -47,13 +129,7 @@ func readWorkTreeStatusFromOutput(statusString string) (WorkTreeStatus, error) {
 		firstComponent = components[0]
 	}
 	if strings.HasPrefix(firstComponent, branchComponentPrefix) {
-		if len(firstComponent) >= 3 {
-			branch = firstComponent[3:]
-			branchComponents := strings.Split(branch, "...")
-			if len(branchComponents) > 0 {
-				cleanedBranch = branchComponents[0]
-			}
-		}
+		status = readBranchFromOutputComponent(firstComponent)
 		startIdx = 1
 	}
 
@@ -69,7 +145,33 @@ func readWorkTreeStatusFromOutput(statusString string) (WorkTreeStatus, error) {
 		files = append(files, file)
 	}
 
-	return WorkTreeStatus{Branch: branch, CleanedBranchName: cleanedBranch, FileStatusList: files}, nil
+	status.FileStatusList = files
+	return status, nil
+}
+
+// readBranchFromOutputComponent reads the branch header of the short status format,
+// e.g. `## main...origin/main [ahead 1, behind 2]`.
+func readBranchFromOutputComponent(component string) WorkTreeStatus {
+	var status WorkTreeStatus
+	if len(component) < 3 {
+		return status
+	}
+
+	status.Branch = component[3:]
+	branch := status.Branch
+
+	if matches := branchTrackingRegex.FindStringSubmatch(branch); matches != nil {
+		status.Ahead, _ = strconv.Atoi(matches[1])
+		status.Behind, _ = strconv.Atoi(matches[2])
+		branch = strings.TrimSpace(strings.TrimSuffix(branch, matches[0]))
+	}
+
+	branchComponents := strings.Split(branch, upstreamSeparator)
+	status.CleanedBranchName = branchComponents[0]
+	if len(branchComponents) > 1 {
+		status.Upstream = branchComponents[1]
+	}
+	return status
 }
 
 // FileStatus represents the git status of a file.
@@ -79,6 +181,11 @@ type FileStatus struct {
 	Extra              string     // Contains extra information, e.g. old name
 	UnstagedStatusCode StatusCode // Working tree status
 	StagedStatusCode   StatusCode // Index status
+	Submodule          SubmoduleStatus
+	// IgnoreRule is the rule that ignores the file, if it is ignored.
+	// It is empty if the file was not checked or no single rule matches, e.g.
+	// for directories whose files are all ignored by different rules.
+	IgnoreRule IgnoreRule
 }
 
 func readFileStatusFromOutputComponent(component string) (FileStatus, error) {
@@ -109,6 +216,46 @@ func readFileStatusFromOutputComponent(component string) (FileStatus, error) {
 	return fileStatus, nil
 }
 
+// readNameStatusFromOutput reads the NUL terminated output of `--name-status -z`,
+// e.g. `M\x00path\x00R100\x00old\x00new\x00`. The change of each file is
+// reported as UnstagedStatusCode. The old path of renamed or copied files is
+// stored in Extra.
+func readNameStatusFromOutput(out string) FileStatusList {
+	var (
+		files      FileStatusList
+		components = strings.Split(out, nulSeparator)
+	)
+
+	for i := 0; i < len(components); i++ {
+		status := components[i]
+		if len(status) == 0 {
+			continue
+		}
+
+		code := StatusCode(status[0])
+		pathCount := 1
+		if code == Renamed || code == Copied {
+			pathCount = 2
+		}
+		if i+pathCount >= len(components) {
+			break
+		}
+
+		file := FileStatus{
+			Path:               components[i+pathCount],
+			UnstagedStatusCode: code,
+			StagedStatusCode:   Unmodified,
+		}
+		if pathCount == 2 {
+			file.Extra = components[i+1]
+		}
+		files = append(files, file)
+		i += pathCount
+	}
+
+	return files
+}
+
 func cleanedPathString(path string) string {
 	path = strings.Trim(path, " ")
 	path = strings.Trim(path, "\"")
@@ -133,6 +280,15 @@ func (fs FileStatus) IsUntracked() bool {
 		fs.StagedStatusCode == Untracked
 }
 
+func (fs FileStatus) IsIgnored() bool {
+	return fs.UnstagedStatusCode == Ignored ||
+		fs.StagedStatusCode == Ignored
+}
+
+func (fs FileStatus) IsSubmodule() bool {
+	return fs.Submodule.IsSubmodule
+}
+
 func (fs FileStatus) IsRenamed() bool {
 	return fs.StagedStatusCode == Renamed || fs.UnstagedStatusCode == Renamed
 }
@@ -142,14 +298,22 @@ type FileStatusList []FileStatus
 // UnstagedFiles returns all files that have unstaged changes.
 func (fl FileStatusList) UnstagedFiles() FileStatusList {
 	return fl.Filter(func(fs FileStatus) bool {
-		return fs.HasUnstagedChanges()
+		return fs.HasUnstagedChanges() && !fs.IsIgnored()
+	})
+}
+
+// IgnoredFiles returns all ignored files.
+// They are only reported if the status was loaded with ShowIgnored.
+func (fl FileStatusList) IgnoredFiles() FileStatusList {
+	return fl.Filter(func(fs FileStatus) bool {
+		return fs.IsIgnored()
 	})
 }
 
 // StagedFiles returns all files that have staged changes.
 func (fl FileStatusList) StagedFiles() FileStatusList {
 	return fl.Filter(func(fs FileStatus) bool {
-		return fs.HasStagedChanges() && !fs.IsUntracked()
+		return fs.HasStagedChanges() && !fs.IsUntracked() && !fs.IsIgnored()
 	})
 }
 
@@ -197,4 +361,5 @@ const (
 	nulSeparator          string = "\000"
 	branchComponentPrefix string = "##"
 	renamePathSeparator   string = "->"
+	upstreamSeparator     string = "..."
 )
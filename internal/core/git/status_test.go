@@ -2,6 +2,8 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -38,6 +40,46 @@ func TestWorkTreeStatusBranch(t *testing.T) {
 	}
 }
 
+func TestWorkTreeStatusBranchTracking(t *testing.T) {
+	tests := []struct {
+		component      string
+		expectBranch   string
+		expectUpstream string
+		expectAhead    int
+		expectBehind   int
+	}{
+		{"## main", "main", "", 0, 0},
+		{"## main...origin/main", "main", "origin/main", 0, 0},
+		{"## main...origin/main [ahead 1]", "main", "origin/main", 1, 0},
+		{"## main...origin/main [behind 12]", "main", "origin/main", 0, 12},
+		{"## main...origin/main [ahead 3, behind 2]", "main", "origin/main", 3, 2},
+		{"## main...origin/main [gone]", "main", "origin/main", 0, 0},
+		{"## HEAD (no branch)", "HEAD (no branch)", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			workTreeStatus, err := readWorkTreeStatusFromOutput(tt.component)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if workTreeStatus.CleanedBranchName != tt.expectBranch {
+				t.Errorf("Got branch '%s', expected '%s'", workTreeStatus.CleanedBranchName, tt.expectBranch)
+			}
+			if workTreeStatus.Upstream != tt.expectUpstream {
+				t.Errorf("Got upstream '%s', expected '%s'", workTreeStatus.Upstream, tt.expectUpstream)
+			}
+			if workTreeStatus.Ahead != tt.expectAhead || workTreeStatus.Behind != tt.expectBehind {
+				t.Errorf(
+					"Got ahead %d behind %d, expected ahead %d behind %d",
+					workTreeStatus.Ahead, workTreeStatus.Behind,
+					tt.expectAhead, tt.expectBehind,
+				)
+			}
+		})
+	}
+}
+
 func TestWorkTreeStatusRenamed(t *testing.T) {
 	var (
 		changes             = "R "
@@ -201,6 +243,171 @@ func TestUntrackedFileStatus(t *testing.T) {
 	}
 }
 
+func TestReadNameStatusFromOutput(t *testing.T) {
+	out := strings.Join([]string{"M", "a.txt", "R100", "old.txt", "new.txt", "A", "dir/b.txt", ""}, nulSeparator)
+	expect := FileStatusList{
+		{Path: "a.txt", UnstagedStatusCode: Modified, StagedStatusCode: Unmodified},
+		{Path: "new.txt", Extra: "old.txt", UnstagedStatusCode: Renamed, StagedStatusCode: Unmodified},
+		{Path: "dir/b.txt", UnstagedStatusCode: Added, StagedStatusCode: Unmodified},
+	}
+
+	got := readNameStatusFromOutput(out)
+
+	if len(got) != len(expect) {
+		t.Fatalf("Got %d files, expected %d: %+v", len(got), len(expect), got)
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("Got %+v, expected %+v", got[i], expect[i])
+		}
+	}
+}
+
+func TestReadNameStatusFromTruncatedOutput(t *testing.T) {
+	out := strings.Join([]string{"M", "a.txt", "R100", "old.txt"}, nulSeparator)
+
+	got := readNameStatusFromOutput(out)
+
+	if len(got) != 1 || got[0].Path != "a.txt" {
+		t.Errorf("Expected only the complete entry, got %+v", got)
+	}
+}
+
 func statusOutputFromComponents(components []string) string {
 	return strings.Join(components, "\n")
 }
+
+func TestIgnoredFileStatus(t *testing.T) {
+	status, err := readWorkTreeStatusFromOutput("## main\n?? new.txt\n!! build/\n")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if got := status.UnstagedFiles(); len(got) != 1 || got[0].Path != "new.txt" {
+		t.Errorf("Expected only new.txt to be unstaged, got %+v", got)
+	}
+	if got := status.IgnoredFiles(); len(got) != 1 || got[0].Path != "build/" || !got[0].IsIgnored() {
+		t.Errorf("Expected build/ to be ignored, got %+v", got)
+	}
+	if got := status.StagedFiles(); len(got) != 0 {
+		t.Errorf("Expected no staged files, got %+v", got)
+	}
+}
+
+func TestStatusOptions(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, ".gitignore", "# build output\n*.log\nbuild/\n")
+	for _, name := range []string{"new/a.txt", "new/b.txt", "build/out", "debug.log"} {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths := func(files FileStatusList) string {
+		var paths []string
+		for _, file := range files {
+			paths = append(paths, file.Path)
+		}
+		return strings.Join(paths, " ")
+	}
+
+	t.Chdir(repo)
+	tests := []struct {
+		opts           StatusOptions
+		expectUnstaged string
+		expectIgnored  string
+	}{
+		{opts: StatusOptions{UntrackedFiles: UntrackedFilesNo}},
+		{opts: StatusOptions{UntrackedFiles: UntrackedFilesNormal}, expectUnstaged: "new/"},
+		{opts: StatusOptions{UntrackedFiles: UntrackedFilesAll}, expectUnstaged: "new/a.txt new/b.txt"},
+		{
+			opts:           StatusOptions{UntrackedFiles: UntrackedFilesNormal, ShowIgnored: true},
+			expectUnstaged: "new/",
+			expectIgnored:  "build/ debug.log",
+		},
+	}
+
+	for _, test := range tests {
+		status, err := Status(test.opts)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if got := paths(status.UnstagedFiles()); got != test.expectUnstaged {
+			t.Errorf("%+v: Got unstaged '%s', expected '%s'", test.opts, got, test.expectUnstaged)
+		}
+		if got := paths(status.IgnoredFiles()); got != test.expectIgnored {
+			t.Errorf("%+v: Got ignored '%s', expected '%s'", test.opts, got, test.expectIgnored)
+		}
+	}
+
+	status, err := Status(StatusOptions{ShowIgnored: true})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expect := map[string]string{
+		"build/":    ".gitignore:3:build/",
+		"debug.log": ".gitignore:2:*.log",
+	}
+	for _, file := range status.IgnoredFiles() {
+		if got := file.IgnoreRule.String(); got != expect[file.Path] {
+			t.Errorf("Got rule '%s' for %s, expected '%s'", got, file.Path, expect[file.Path])
+		}
+	}
+}
+
+func TestStageAndUnstageFiles(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(repo)
+	if err := StageFiles([]string{"a.txt", "c.txt"}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if got := runGit(t, repo, "diff", "--cached", "--name-only"); got != "a.txt\nc.txt" {
+		t.Errorf("Expected a.txt and c.txt to be staged, got %q", got)
+	}
+
+	if err := UnstageFiles([]string{"c.txt"}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if got := runGit(t, repo, "diff", "--cached", "--name-only"); got != "a.txt" {
+		t.Errorf("Expected only a.txt to be staged, got %q", got)
+	}
+}
+
+func TestResetFiles(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	commitFile(t, repo, "a.txt", "a")
+	for name, content := range map[string]string{"a.txt": "changed", "README.md": "changed", "new.txt": "new"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(repo)
+	status, err := Status(StatusOptions{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	var files FileStatusList
+	for _, file := range status.UnstagedFiles() {
+		if file.Path != "README.md" {
+			files = append(files, file)
+		}
+	}
+
+	if err := ResetFiles(files); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if got := runGit(t, repo, "status", "--porcelain"); got != "M README.md" {
+		t.Errorf("Expected only README.md to be modified, got %q", got)
+	}
+}
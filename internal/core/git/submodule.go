@@ -0,0 +1,143 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SubmoduleStatus is the state of a submodule as reported by `git status --porcelain=2`.
+type SubmoduleStatus struct {
+	IsSubmodule bool
+	// HasNewCommits is true if the checked out commit differs from the recorded one.
+	HasNewCommits bool
+	// HasModifiedContent is true if tracked files of the submodule are modified.
+	HasModifiedContent bool
+	// HasUntrackedContent is true if the submodule contains untracked files.
+	HasUntrackedContent bool
+}
+
+// readSubmoduleStatus reads the submodule field of a porcelain v2 entry,
+// e.g. `N...` for regular files or `SCMU` for a submodule with all changes.
+func readSubmoduleStatus(field string) SubmoduleStatus {
+	if len(field) != 4 || field[0] != 'S' {
+		return SubmoduleStatus{}
+	}
+	return SubmoduleStatus{
+		IsSubmodule:         true,
+		HasNewCommits:       field[1] == 'C',
+		HasModifiedContent:  field[2] == 'M',
+		HasUntrackedContent: field[3] == 'U',
+	}
+}
+
+// readSubmoduleStatusFromPorcelainV2Output reads the submodule state of all changed
+// submodules from the NUL terminated output of `git status --porcelain=2 -z`.
+func readSubmoduleStatusFromPorcelainV2Output(out string) map[string]SubmoduleStatus {
+	var (
+		states     = make(map[string]SubmoduleStatus)
+		components = strings.Split(out, nulSeparator)
+	)
+
+	for i := 0; i < len(components); i++ {
+		component := components[i]
+		if len(component) < 2 {
+			continue
+		}
+
+		// Number of space separated fields, including the path.
+		var fieldCount int
+		switch component[0] {
+		case '1':
+			fieldCount = 9
+		case '2':
+			fieldCount = 10
+			// The original path follows as its own component.
+			i++
+		case 'u':
+			fieldCount = 11
+		default:
+			continue
+		}
+
+		fields := strings.SplitN(component, " ", fieldCount)
+		if len(fields) != fieldCount {
+			continue
+		}
+		if state := readSubmoduleStatus(fields[2]); state.IsSubmodule {
+			states[fields[fieldCount-1]] = state
+		}
+	}
+
+	return states
+}
+
+// submodulePaths returns the paths of the submodules configured in .gitmodules,
+// relative to the root of the work tree.
+func submodulePaths() ([]string, error) {
+	root, err := RootFolder()
+	if err != nil {
+		return nil, err
+	}
+	gitmodules := filepath.Join(root, ".gitmodules")
+	if _, err := os.Stat(gitmodules); err != nil {
+		return nil, nil
+	}
+
+	out, err := newGitCommand(
+		"config", "--file", gitmodules, "--get-regexp", `^submodule\..*\.path$`,
+	).output()
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, path, ok := strings.Cut(line, " "); ok && len(path) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// loadSubmoduleStatus returns the state of all changed submodules by their path.
+// Only the submodules are checked, to not read the whole work tree a second time.
+func loadSubmoduleStatus(paths []string) (map[string]SubmoduleStatus, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	root, err := RootFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"-C", root, "status", "--porcelain=2", "-z", "--ignore-submodules=none", "--"}
+	out, err := newGitCommand(append(args, paths...)...).output()
+	if err != nil {
+		return nil, err
+	}
+	return readSubmoduleStatusFromPorcelainV2Output(out), nil
+}
+
+// UpdateSubmodules initializes and updates all submodules recursively.
+func UpdateSubmodules(onProgress ProgressHandler) error {
+	return newGitCommand("submodule", "update", "--init", "--recursive", "--progress").
+		withoutTerminalPrompt().
+		runWithProgress(onProgress)
+}
+
+// SubmodulePath returns the absolute path of the submodule at the given path,
+// which is relative to the root of the work tree.
+func SubmodulePath(path string) (string, error) {
+	root, err := RootFolder()
+	if err != nil {
+		return "", err
+	}
+	absPath := filepath.Join(root, path)
+	if _, err := os.Stat(filepath.Join(absPath, ".git")); err != nil {
+		return "", errors.New("Submodule is not initialized. Please update the submodules first.")
+	}
+	return absPath, nil
+}
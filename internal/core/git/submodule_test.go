@@ -0,0 +1,111 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSubmoduleStatusFromPorcelainV2Output(t *testing.T) {
+	out := strings.Join([]string{
+		"1 .M SC.. 160000 160000 160000 abc abc lib/commits",
+		"1 .M S.MU 160000 160000 160000 abc abc lib/with space",
+		"1 .M N... 100644 100644 100644 abc abc file.txt",
+		"2 R. N... 100644 100644 100644 abc abc R100 new.txt",
+		"old.txt",
+		"u UU S.M. 160000 160000 160000 160000 abc abc abc lib/conflict",
+		"? untracked",
+		"",
+	}, nulSeparator)
+
+	expect := map[string]SubmoduleStatus{
+		"lib/commits":    {IsSubmodule: true, HasNewCommits: true},
+		"lib/with space": {IsSubmodule: true, HasModifiedContent: true, HasUntrackedContent: true},
+		"lib/conflict":   {IsSubmodule: true, HasModifiedContent: true},
+	}
+
+	got := readSubmoduleStatusFromPorcelainV2Output(out)
+	if len(got) != len(expect) {
+		t.Fatalf("Got %+v, expected %+v", got, expect)
+	}
+	for path, state := range expect {
+		if got[path] != state {
+			t.Errorf("Got %+v for %s, expected %+v", got[path], path, state)
+		}
+	}
+}
+
+// newSubmoduleSetup returns a repository with the submodule `lib`.
+func newSubmoduleSetup(t *testing.T) (repo string) {
+	t.Helper()
+	bare, repo := newRemoteSetup(t)
+	// Local submodules are not allowed by default.
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	runGit(t, repo, "submodule", "add", bare, "lib")
+	runGit(t, repo, "commit", "-m", "add submodule")
+	return repo
+}
+
+func TestSubmoduleStatus(t *testing.T) {
+	repo := newSubmoduleSetup(t)
+	lib := filepath.Join(repo, "lib")
+	commitFile(t, lib, "new.txt", "new")
+	if err := os.WriteFile(filepath.Join(lib, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(lib, "untracked.txt"), []byte("untracked"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(repo)
+	status, err := Status(StatusOptions{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !status.HasSubmodules {
+		t.Error("Expected repository to have submodules")
+	}
+	if len(status.FileStatusList) != 1 {
+		t.Fatalf("Got files %+v, expected only lib", status.FileStatusList)
+	}
+
+	expect := SubmoduleStatus{IsSubmodule: true, HasNewCommits: true, HasModifiedContent: true, HasUntrackedContent: true}
+	if file := status.FileStatusList[0]; file.Path != "lib" || file.Submodule != expect {
+		t.Errorf("Got %+v, expected submodule state %+v", file, expect)
+	}
+
+	diff, err := Diff(DiffOptions{FilePath: "lib"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !strings.Contains(diff, "> update new.txt") {
+		t.Errorf("Expected commit log in diff, got:\n%s", diff)
+	}
+}
+
+func TestUpdateSubmodules(t *testing.T) {
+	repo := newSubmoduleSetup(t)
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, repo, "clone", "-q", repo, clone)
+
+	t.Chdir(clone)
+	if _, err := SubmodulePath("lib"); err == nil {
+		t.Error("Expected error for uninitialized submodule")
+	}
+
+	if err := UpdateSubmodules(nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	path, err := SubmodulePath("lib")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "README.md")); err != nil {
+		t.Error("Expected submodule to be checked out:", err)
+	}
+}
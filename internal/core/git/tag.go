@@ -0,0 +1,148 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tagFormat is the `git for-each-ref` format to read tags.
+// Fields are separated by NUL, tags by newline.
+// For annotated tags `*objectname` is the commit the tag points to.
+const tagFormat = "%(refname:short)%00%(objecttype)%00%(objectname:short)%00%(*objectname:short)%00%(creatordate:unix)%00%(contents:subject)"
+
+// Tag represents a lightweight or annotated git tag.
+type Tag struct {
+	Name string
+	// Abbreviated hash of the commit the tag points to.
+	Target string
+	// IsAnnotated is true for tag objects created with a message.
+	IsAnnotated bool
+	// Tagger date for annotated tags, otherwise the commit date.
+	Date time.Time
+	// Subject of the tag message for annotated tags, otherwise of the commit.
+	Message string
+}
+
+// GetTags returns all tags, newest first.
+func GetTags() ([]Tag, error) {
+	out, err := newGitCommand(
+		"for-each-ref",
+		"--sort=-creatordate",
+		fmt.Sprintf("--format=%s", tagFormat),
+		"refs/tags",
+	).output()
+	if err != nil {
+		return nil, err
+	}
+	return readTagsFromOutput(out), nil
+}
+
+func readTagsFromOutput(out string) []Tag {
+	var tags []Tag
+	for _, line := range strings.Split(out, "\n") {
+		if tag, err := readTagFromOutputLine(line); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func readTagFromOutputLine(line string) (Tag, error) {
+	fields := strings.Split(line, nulSeparator)
+	if len(fields) != 6 || len(fields[0]) == 0 {
+		return Tag{}, errors.New("Can't read tag. Unexpected number of fields.")
+	}
+
+	tag := Tag{
+		Name:        fields[0],
+		Target:      fields[2],
+		IsAnnotated: fields[1] == "tag",
+		Message:     fields[5],
+	}
+	if tag.IsAnnotated && len(fields[3]) > 0 {
+		tag.Target = fields[3]
+	}
+	if seconds, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+		tag.Date = time.Unix(seconds, 0)
+	}
+	return tag, nil
+}
+
+// CreateTagOpts configures the creation of a tag.
+type CreateTagOpts struct {
+	Name string
+	// Revision to tag. HEAD if empty.
+	Target string
+	// Message of an annotated tag. A lightweight tag is created if empty.
+	Message string
+	// IsSigned creates an annotated tag signed with the default key.
+	// It requires a message.
+	IsSigned bool
+}
+
+// CreateTag creates a new tag.
+func CreateTag(opts CreateTagOpts) error {
+	if len(strings.TrimSpace(opts.Name)) == 0 {
+		return errors.New("Please enter a tag name.")
+	}
+	if opts.IsSigned && len(opts.Message) == 0 {
+		return errors.New("Signed tags require a message.")
+	}
+
+	args := []string{"tag"}
+
+	if opts.IsSigned {
+		args = append(args, "-s")
+	}
+
+	if len(opts.Message) > 0 {
+		args = append(args, "-a", "-m", opts.Message)
+	}
+
+	args = append(args, "--", opts.Name)
+
+	if len(opts.Target) > 0 {
+		args = append(args, opts.Target)
+	}
+
+	return newGitCommand(args...).runStrict()
+}
+
+// DeleteTag deletes the local tag with the given name.
+func DeleteTag(name string) error {
+	return newGitCommand("tag", "-d", name).runStrict()
+}
+
+// PushTag pushes a single tag to the push remote.
+func PushTag(name string, onProgress ProgressHandler) error {
+	return pushRefs(onProgress, fmt.Sprintf("refs/tags/%s", name))
+}
+
+// PushAllTags pushes all tags to the push remote.
+func PushAllTags(onProgress ProgressHandler) error {
+	return pushRefs(onProgress, "--tags")
+}
+
+func pushRefs(onProgress ProgressHandler, refs ...string) error {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return err
+	}
+	remote, err := pushRemote(branch)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"push", "--progress", remote}, refs...)
+	return newGitCommand(args...).
+		withoutTerminalPrompt().
+		runWithProgress(onProgress)
+}
+
+// CheckoutTag checks out the commit of the tag in detached HEAD state.
+func CheckoutTag(name string) error {
+	return newGitCommand("switch", "--detach", fmt.Sprintf("refs/tags/%s", name)).runStrict()
+}
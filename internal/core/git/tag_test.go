@@ -0,0 +1,113 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReadTagFromOutputLine(t *testing.T) {
+	tests := []struct {
+		input    string
+		expect   Tag
+		hasError bool
+	}{
+		{
+			input:  fmt.Sprintf("v1.0%[1]scommit%[1]sabc1234%[1]s%[1]s0%[1]sInitial commit", nulSeparator),
+			expect: Tag{Name: "v1.0", Target: "abc1234", Message: "Initial commit"},
+		},
+		{
+			input:  fmt.Sprintf("v2.0%[1]stag%[1]sfff0000%[1]sabc1234%[1]s0%[1]sRelease: 2.0", nulSeparator),
+			expect: Tag{Name: "v2.0", Target: "abc1234", IsAnnotated: true, Message: "Release: 2.0"},
+		},
+		{input: "", hasError: true},
+		{input: "v1.0 abc1234", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Reading tag from: `%q`", tt.input), func(t *testing.T) {
+			got, err := readTagFromOutputLine(tt.input)
+			if (tt.hasError && err == nil) || (!tt.hasError && err != nil) {
+				t.Fatal("Unexpected error result:", err)
+			}
+			if tt.hasError {
+				return
+			}
+			got.Date = tt.expect.Date
+			if got != tt.expect {
+				t.Errorf("Got %+v, expected %+v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	bare, clone := newRemoteSetup(t)
+	first := runGit(t, clone, "rev-parse", "--short", "HEAD")
+	commitFile(t, clone, "file.txt", "second")
+	second := runGit(t, clone, "rev-parse", "--short", "HEAD")
+
+	t.Chdir(clone)
+	if err := CreateTag(CreateTagOpts{Name: "v1.0", Target: first}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := CreateTag(CreateTagOpts{Name: "v2.0", Message: "Release 2.0"}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := CreateTag(CreateTagOpts{Name: "v2.0"}); err == nil {
+		t.Error("Expected error for existing tag")
+	}
+	if err := CreateTag(CreateTagOpts{Name: "signed", IsSigned: true}); err == nil {
+		t.Error("Expected error for signed tag without message")
+	}
+
+	tags, err := GetTags()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expect := map[string]Tag{
+		"v1.0": {Name: "v1.0", Target: first, Message: "update README.md"},
+		"v2.0": {Name: "v2.0", Target: second, IsAnnotated: true, Message: "Release 2.0"},
+	}
+	if len(tags) != len(expect) {
+		t.Fatalf("Got %d tags, expected %d", len(tags), len(expect))
+	}
+	for _, tag := range tags {
+		if tag.Date.IsZero() {
+			t.Errorf("Missing date for %s", tag.Name)
+		}
+		tag.Date = expect[tag.Name].Date
+		if tag != expect[tag.Name] {
+			t.Errorf("Got %+v, expected %+v", tag, expect[tag.Name])
+		}
+	}
+
+	if err := PushTag("v1.0", nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if remoteTags := runGit(t, bare, "tag"); remoteTags != "v1.0" {
+		t.Errorf("Got remote tags '%s', expected 'v1.0'", remoteTags)
+	}
+	if err := PushAllTags(nil); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if remoteTags := runGit(t, bare, "tag"); remoteTags != "v1.0\nv2.0" {
+		t.Errorf("Got remote tags '%s', expected 'v1.0 v2.0'", remoteTags)
+	}
+
+	if err := CheckoutTag("v1.0"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if branch, _ := CurrentBranch(); len(branch) > 0 {
+		t.Errorf("Expected detached HEAD, got branch '%s'", branch)
+	}
+	if head := runGit(t, clone, "rev-parse", "--short", "HEAD"); head != first {
+		t.Errorf("Got HEAD '%s', expected '%s'", head, first)
+	}
+
+	if err := DeleteTag("v2.0"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if localTags := runGit(t, clone, "tag"); localTags != "v1.0" {
+		t.Errorf("Got local tags '%s', expected 'v1.0'", localTags)
+	}
+}
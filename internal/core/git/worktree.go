@@ -0,0 +1,167 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is a work tree of the repository as listed by `git worktree list`.
+type Worktree struct {
+	// Absolute path of the work tree.
+	Path string
+	// Hash of the checked out commit.
+	Head string
+	// Short name of the checked out branch. Empty if detached or bare.
+	Branch     string
+	IsBare     bool
+	IsDetached bool
+	IsLocked   bool
+	LockReason string
+	// IsPrunable is true if the work tree is missing and can be pruned.
+	IsPrunable     bool
+	PrunableReason string
+	// IsCurrent is true for the work tree gitglance runs in.
+	IsCurrent bool
+}
+
+// GetWorktrees returns all work trees, starting with the main work tree.
+func GetWorktrees() ([]Worktree, error) {
+	out, err := newGitCommand("worktree", "list", "--porcelain", "-z").output()
+	if err != nil {
+		return nil, err
+	}
+	worktrees := readWorktreesFromOutput(out)
+
+	if root, err := RootFolder(); err == nil {
+		root = evalSymlinks(root)
+		for i := range worktrees {
+			worktrees[i].IsCurrent = evalSymlinks(worktrees[i].Path) == root
+		}
+	}
+	return worktrees, nil
+}
+
+// readWorktreesFromOutput reads the output of `git worktree list --porcelain -z`.
+// Attributes are terminated by NUL, work trees by an additional NUL.
+func readWorktreesFromOutput(out string) []Worktree {
+	var (
+		worktrees []Worktree
+		current   *Worktree
+	)
+
+	for _, attribute := range strings.Split(out, nulSeparator) {
+		if len(attribute) == 0 {
+			current = nil
+			continue
+		}
+
+		name, value, _ := strings.Cut(attribute, " ")
+		if name == "worktree" {
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch name {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.IsBare = true
+		case "detached":
+			current.IsDetached = true
+		case "locked":
+			current.IsLocked = true
+			current.LockReason = value
+		case "prunable":
+			current.IsPrunable = true
+			current.PrunableReason = value
+		}
+	}
+
+	return worktrees
+}
+
+// CreateWorktreeOpts configures the creation of a work tree.
+type CreateWorktreeOpts struct {
+	// Path of the new work tree.
+	Path string
+	// Branch to check out. If NewBranch is set, the revision to start it at.
+	Branch string
+	// NewBranch is the name of a branch to create. Optional.
+	NewBranch string
+}
+
+// CreateWorktree creates a new work tree with the given options.
+func CreateWorktree(opts CreateWorktreeOpts) error {
+	if len(opts.Path) == 0 {
+		return errors.New("Missing path.")
+	}
+
+	args := []string{"worktree", "add"}
+	if len(opts.NewBranch) > 0 {
+		args = append(args, "-b", opts.NewBranch)
+	}
+	args = append(args, opts.Path)
+	if len(opts.Branch) > 0 {
+		args = append(args, opts.Branch)
+	}
+	return newGitCommand(args...).runStrict()
+}
+
+// RemoveWorktree removes the work tree at path.
+// Unless force is set, git refuses to remove work trees with local changes.
+func RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	return newGitCommand(append(args, path)...).runStrict()
+}
+
+// LockWorktree prevents the work tree at path from being pruned, moved or removed.
+func LockWorktree(path string, reason string) error {
+	args := []string{"worktree", "lock"}
+	if len(reason) > 0 {
+		args = append(args, "--reason", reason)
+	}
+	return newGitCommand(append(args, path)...).runStrict()
+}
+
+// UnlockWorktree unlocks the work tree at path.
+func UnlockWorktree(path string) error {
+	return newGitCommand("worktree", "unlock", path).runStrict()
+}
+
+// SwitchWorktree changes the working directory of the process to the work tree at path.
+// All following git commands run inside of it.
+func SwitchWorktree(path string) error {
+	previousPath, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(path); err != nil {
+		return err
+	}
+	if !IsInWorkTree() {
+		// Stay inside of the previous work tree.
+		_ = os.Chdir(previousPath)
+		return errors.New("Not a git work tree.")
+	}
+	return nil
+}
+
+// evalSymlinks returns the path with resolved symlinks or the
+// unchanged path if they can't be resolved.
+func evalSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
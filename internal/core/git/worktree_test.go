@@ -0,0 +1,126 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadWorktreesFromOutput(t *testing.T) {
+	out := strings.Join([]string{
+		"worktree /repo", "HEAD 1111", "branch refs/heads/main", "",
+		"worktree /repo-feature", "HEAD 2222", "branch refs/heads/feature/x", "locked", "",
+		"worktree /repo-detached", "HEAD 3333", "detached", "locked reason with spaces", "prunable gitdir file points to non-existent location", "",
+		"",
+	}, nulSeparator)
+
+	expect := []Worktree{
+		{Path: "/repo", Head: "1111", Branch: "main"},
+		{Path: "/repo-feature", Head: "2222", Branch: "feature/x", IsLocked: true},
+		{
+			Path:           "/repo-detached",
+			Head:           "3333",
+			IsDetached:     true,
+			IsLocked:       true,
+			LockReason:     "reason with spaces",
+			IsPrunable:     true,
+			PrunableReason: "gitdir file points to non-existent location",
+		},
+	}
+
+	got := readWorktreesFromOutput(out)
+	if len(got) != len(expect) {
+		t.Fatalf("Got %+v, expected %+v", got, expect)
+	}
+	for i := range expect {
+		if got[i] != expect[i] {
+			t.Errorf("Got %+v, expected %+v", got[i], expect[i])
+		}
+	}
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	runGit(t, repo, "branch", "feature")
+	linked := filepath.Join(filepath.Dir(repo), "linked")
+
+	t.Chdir(repo)
+	if err := CreateWorktree(CreateWorktreeOpts{Path: linked, Branch: "feature"}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := CreateWorktree(CreateWorktreeOpts{Path: linked + "-new", Branch: "main", NewBranch: "new"}); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := LockWorktree(linked, "in review"); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	worktrees, err := GetWorktrees()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(worktrees) != 3 {
+		t.Fatalf("Got %d work trees, expected 3", len(worktrees))
+	}
+	if !worktrees[0].IsCurrent || worktrees[0].Branch != "main" {
+		t.Errorf("Expected current main work tree, got %+v", worktrees[0])
+	}
+	if wt := worktrees[1]; wt.IsCurrent || wt.Branch != "feature" || !wt.IsLocked || wt.LockReason != "in review" {
+		t.Errorf("Expected locked feature work tree, got %+v", wt)
+	}
+	if wt := worktrees[2]; wt.Branch != "new" {
+		t.Errorf("Expected work tree on new branch, got %+v", wt)
+	}
+
+	if err := RemoveWorktree(linked, false); err == nil {
+		t.Error("Expected locked work tree not to be removed")
+	}
+	if err := UnlockWorktree(linked); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if err := os.WriteFile(filepath.Join(linked, "README.md"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveWorktree(linked, false); err == nil {
+		t.Error("Expected modified work tree not to be removed without force")
+	}
+	if err := RemoveWorktree(linked, true); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if _, err := os.Stat(linked); !os.IsNotExist(err) {
+		t.Error("Expected work tree to be removed")
+	}
+}
+
+func TestMergeMsgInLinkedWorktree(t *testing.T) {
+	_, repo := newRemoteSetup(t)
+	runGit(t, repo, "branch", "feature")
+	commitFile(t, repo, "README.md", "main")
+	linked := filepath.Join(filepath.Dir(repo), "linked")
+	runGit(t, repo, "worktree", "add", linked, "feature")
+	commitFile(t, linked, "README.md", "feature")
+	runGit(t, linked, "merge", "--no-commit", "--no-ff", "-m", "merge main", "main", "-s", "ours")
+
+	// Restores the working directory once the test finished.
+	t.Chdir(repo)
+	if err := SwitchWorktree(linked); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	root, err := RootFolder()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if evalSymlinks(root) != evalSymlinks(linked) {
+		t.Errorf("Got root folder '%s', expected '%s'", root, linked)
+	}
+
+	msg, err := MergeMsg()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if !strings.HasPrefix(msg, "merge main") {
+		t.Errorf("Got merge message '%s'", msg)
+	}
+}
@@ -15,3 +15,14 @@ func Schedule(d time.Duration) tea.Cmd {
 		return Msg(t)
 	})
 }
+
+// FetchMsg produced by ScheduleFetch command
+type FetchMsg time.Time
+
+// ScheduleFetch produces a FetchMsg after the given duration.
+// It is scheduled independently of Msg, as fetching from a remote is more expensive.
+func ScheduleFetch(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return FetchMsg(t)
+	})
+}
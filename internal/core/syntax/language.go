@@ -0,0 +1,203 @@
+package syntax
+
+import (
+	"path"
+	"strings"
+)
+
+// Language describes the syntax of a programming language for the lexer.
+type Language struct {
+	Name                      string
+	keywords                  map[string]bool
+	lineComments              []string
+	blockCommentStart         string
+	blockCommentEnd           string
+	stringDelimiters          []string
+	multilineStringDelimiters []string
+}
+
+func newLanguage(name string, keywords string) *Language {
+	language := &Language{Name: name, keywords: make(map[string]bool)}
+	for _, keyword := range strings.Fields(keywords) {
+		language.keywords[keyword] = true
+	}
+	return language
+}
+
+func (l *Language) withLineComments(prefixes ...string) *Language {
+	l.lineComments = prefixes
+	return l
+}
+
+func (l *Language) withBlockComment(start, end string) *Language {
+	l.blockCommentStart, l.blockCommentEnd = start, end
+	return l
+}
+
+func (l *Language) withStrings(delimiters ...string) *Language {
+	l.stringDelimiters = delimiters
+	return l
+}
+
+func (l *Language) withMultilineStrings(delimiters ...string) *Language {
+	l.multilineStringDelimiters = delimiters
+	return l
+}
+
+var (
+	golang = newLanguage("go", `break case chan const continue default defer else fallthrough for func go goto
+		if import interface map package range return select struct switch type var
+		true false nil iota any bool byte error int int8 int16 int32 int64 rune string
+		uint uint8 uint16 uint32 uint64 uintptr float32 float64 complex64 complex128`).
+		withLineComments("//").
+		withBlockComment("/*", "*/").
+		withStrings(`"`, "'").
+		withMultilineStrings("`")
+
+	cLike = newLanguage("c", `auto break case char const continue default do double else enum extern float for
+		goto if inline int long register return short signed sizeof static struct switch typedef union
+		unsigned void volatile while bool true false NULL nullptr class namespace template typename
+		public private protected virtual override new delete this throw try catch using`).
+		withLineComments("//").
+		withBlockComment("/*", "*/").
+		withStrings(`"`, "'")
+
+	java = newLanguage("java", `abstract assert boolean break byte case catch char class const continue default
+		do double else enum extends final finally float for if implements import instanceof int interface
+		long native new package private protected public return short static super switch synchronized
+		this throw throws try void volatile while true false null var val fun when object data sealed
+		override open internal lateinit companion`).
+		withLineComments("//").
+		withBlockComment("/*", "*/").
+		withStrings(`"`, "'").
+		withMultilineStrings(`"""`)
+
+	javaScript = newLanguage("javascript", `async await break case catch class const continue debugger default delete
+		do else export extends finally for from function if import in instanceof let new of return static
+		super switch this throw try typeof var void while with yield true false null undefined
+		interface type enum implements private public protected readonly as`).
+		withLineComments("//").
+		withBlockComment("/*", "*/").
+		withStrings(`"`, "'").
+		withMultilineStrings("`")
+
+	rust = newLanguage("rust", `as async await break const continue crate dyn else enum extern false fn for if
+		impl in let loop match mod move mut pub ref return self Self static struct super trait true type
+		unsafe use where while`).
+		withLineComments("//").
+		withBlockComment("/*", "*/").
+		withStrings(`"`)
+
+	python = newLanguage("python", `and as assert async await break class continue def del elif else except
+		False finally for from global if import in is lambda None nonlocal not or pass raise return True
+		try while with yield self`).
+		withLineComments("#").
+		withStrings(`"`, "'").
+		withMultilineStrings(`"""`, `'''`)
+
+	ruby = newLanguage("ruby", `alias and begin break case class def defined do else elsif end ensure false for
+		if in module next nil not or redo rescue retry return self super then true undef unless until
+		when while yield require`).
+		withLineComments("#").
+		withStrings(`"`, "'")
+
+	shell = newLanguage("shell", `if then else elif fi case esac for while until do done in function return
+		local export readonly set unset shift exit echo`).
+		withLineComments("#").
+		withStrings(`"`, "'")
+
+	yaml = newLanguage("yaml", `true false null yes no on off`).
+		withLineComments("#").
+		withStrings(`"`, "'")
+
+	json = newLanguage("json", `true false null`).
+		withStrings(`"`)
+
+	makefile = newLanguage("make", `ifeq ifneq ifdef ifndef else endif include define endef export override`).
+			withLineComments("#")
+)
+
+var languagesByExtension = map[string]*Language{
+	".go":    golang,
+	".c":     cLike,
+	".h":     cLike,
+	".cc":    cLike,
+	".cpp":   cLike,
+	".hpp":   cLike,
+	".cs":    cLike,
+	".m":     cLike,
+	".swift": java,
+	".java":  java,
+	".kt":    java,
+	".kts":   java,
+	".scala": java,
+	".js":    javaScript,
+	".jsx":   javaScript,
+	".mjs":   javaScript,
+	".cjs":   javaScript,
+	".ts":    javaScript,
+	".tsx":   javaScript,
+	".rs":    rust,
+	".py":    python,
+	".rb":    ruby,
+	".sh":    shell,
+	".bash":  shell,
+	".zsh":   shell,
+	".yml":   yaml,
+	".yaml":  yaml,
+	".toml":  yaml,
+	".json":  json,
+	".mk":    makefile,
+}
+
+var languagesByFileName = map[string]*Language{
+	"Makefile":    makefile,
+	"GNUmakefile": makefile,
+	"Dockerfile":  shell,
+	"Gemfile":     ruby,
+	"Rakefile":    ruby,
+	".bashrc":     shell,
+	".zshrc":      shell,
+}
+
+var languagesByInterpreter = map[string]*Language{
+	"sh":      shell,
+	"bash":    shell,
+	"zsh":     shell,
+	"python":  python,
+	"python3": python,
+	"ruby":    ruby,
+	"node":    javaScript,
+}
+
+// Detect returns the language of the file at the path by its extension or name.
+// Files without either are detected by the interpreter of a shebang in the first line.
+func Detect(filePath string, firstLine string) (*Language, bool) {
+	name := path.Base(filePath)
+	if language, ok := languagesByFileName[name]; ok {
+		return language, true
+	}
+	if language, ok := languagesByExtension[strings.ToLower(path.Ext(name))]; ok {
+		return language, true
+	}
+	return detectShebang(firstLine)
+}
+
+// detectShebang detects the language by the interpreter of a shebang,
+// e.g. `#!/bin/sh` or `#!/usr/bin/env python3`.
+func detectShebang(line string) (*Language, bool) {
+	shebang, ok := strings.CutPrefix(line, "#!")
+	if !ok {
+		return nil, false
+	}
+	fields := strings.Fields(shebang)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" && len(fields) > 1 {
+		interpreter = fields[1]
+	}
+	language, ok := languagesByInterpreter[interpreter]
+	return language, ok
+}
@@ -0,0 +1,187 @@
+// Package syntax splits lines of source code into tokens to highlight them.
+// The lexer is intentionally simple: it knows keywords, comments, strings and
+// numbers of common languages, which is enough to highlight lines of a diff.
+package syntax
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Kind is the kind of a token.
+type Kind byte
+
+const (
+	Plain Kind = iota
+	Keyword
+	String
+	Number
+	Comment
+)
+
+// Token is a part of a line of a single kind.
+type Token struct {
+	Kind Kind
+	Text string
+}
+
+// State is carried from one line to the next, e.g. to continue a block comment.
+type State struct {
+	inBlockComment bool
+	// stringDelimiter is set, while inside of a string that spans multiple lines.
+	stringDelimiter string
+}
+
+// Tokenize splits the line into tokens. The state of the previous line is
+// continued and the state for the next line is returned.
+func (l *Language) Tokenize(line string, state State) ([]Token, State) {
+	var (
+		tokens []Token
+		rest   = line
+	)
+	emit := func(kind Kind, text string) {
+		if len(text) == 0 {
+			return
+		}
+		// Consecutive tokens of the same kind are merged.
+		if n := len(tokens); n > 0 && tokens[n-1].Kind == kind {
+			tokens[n-1].Text += text
+			return
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text})
+	}
+
+	for len(rest) > 0 {
+		switch {
+		case state.inBlockComment:
+			end := strings.Index(rest, l.blockCommentEnd)
+			if end < 0 {
+				emit(Comment, rest)
+				return tokens, state
+			}
+			end += len(l.blockCommentEnd)
+			emit(Comment, rest[:end])
+			rest = rest[end:]
+			state.inBlockComment = false
+		case len(state.stringDelimiter) > 0:
+			end := stringEnd(rest, state.stringDelimiter)
+			if end < 0 {
+				emit(String, rest)
+				return tokens, state
+			}
+			emit(String, rest[:end])
+			rest = rest[end:]
+			state.stringDelimiter = ""
+		case l.hasLineCommentPrefix(rest):
+			emit(Comment, rest)
+			return tokens, state
+		case len(l.blockCommentStart) > 0 && strings.HasPrefix(rest, l.blockCommentStart):
+			emit(Comment, l.blockCommentStart)
+			rest = rest[len(l.blockCommentStart):]
+			state.inBlockComment = true
+		default:
+			if delimiter, ok := l.stringDelimiterPrefix(rest); ok {
+				end := stringEnd(rest[len(delimiter):], delimiter)
+				if end < 0 {
+					emit(String, rest)
+					if l.isMultilineDelimiter(delimiter) {
+						state.stringDelimiter = delimiter
+					}
+					return tokens, state
+				}
+				end += len(delimiter)
+				emit(String, rest[:end])
+				rest = rest[end:]
+				continue
+			}
+
+			r := []rune(rest)[0]
+			switch {
+			case unicode.IsDigit(r):
+				end := strings.IndexFunc(rest, func(r rune) bool { return !isNumberRune(r) })
+				if end < 0 {
+					end = len(rest)
+				}
+				emit(Number, rest[:end])
+				rest = rest[end:]
+			case isIdentifierStart(r):
+				end := strings.IndexFunc(rest, func(r rune) bool { return !isIdentifierRune(r) })
+				if end < 0 {
+					end = len(rest)
+				}
+				word := rest[:end]
+				if l.keywords[word] {
+					emit(Keyword, word)
+				} else {
+					emit(Plain, word)
+				}
+				rest = rest[end:]
+			default:
+				size := len(string(r))
+				emit(Plain, rest[:size])
+				rest = rest[size:]
+			}
+		}
+	}
+	return tokens, state
+}
+
+func (l *Language) hasLineCommentPrefix(s string) bool {
+	for _, prefix := range l.lineComments {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Language) stringDelimiterPrefix(s string) (string, bool) {
+	// Longer delimiters, e.g. `"""`, take precedence over their prefixes.
+	for _, delimiter := range l.multilineStringDelimiters {
+		if strings.HasPrefix(s, delimiter) {
+			return delimiter, true
+		}
+	}
+	for _, delimiter := range l.stringDelimiters {
+		if strings.HasPrefix(s, delimiter) {
+			return delimiter, true
+		}
+	}
+	return "", false
+}
+
+func (l *Language) isMultilineDelimiter(delimiter string) bool {
+	for _, multilineDelimiter := range l.multilineStringDelimiters {
+		if multilineDelimiter == delimiter {
+			return true
+		}
+	}
+	return false
+}
+
+// stringEnd returns the index after the closing delimiter in s, or -1.
+// Delimiters escaped with a backslash are skipped.
+func stringEnd(s string, delimiter string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], delimiter) {
+			return i + len(delimiter)
+		}
+	}
+	return -1
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentifierRune(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r)
+}
+
+func isNumberRune(r rune) bool {
+	return r == '.' || r == '_' || unicode.IsDigit(r) || unicode.IsLetter(r)
+}
@@ -0,0 +1,96 @@
+package syntax
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, _ := golang.Tokenize(`	if x := 42; x > 0 { return "a\"b" } // done`, State{})
+	expect := []Token{
+		{Kind: Plain, Text: "\t"},
+		{Kind: Keyword, Text: "if"},
+		{Kind: Plain, Text: " x := "},
+		{Kind: Number, Text: "42"},
+		{Kind: Plain, Text: "; x > "},
+		{Kind: Number, Text: "0"},
+		{Kind: Plain, Text: " { "},
+		{Kind: Keyword, Text: "return"},
+		{Kind: Plain, Text: " "},
+		{Kind: String, Text: `"a\"b"`},
+		{Kind: Plain, Text: " } "},
+		{Kind: Comment, Text: "// done"},
+	}
+	if !reflect.DeepEqual(tokens, expect) {
+		t.Errorf("Got tokens %+v", tokens)
+	}
+}
+
+func TestTokenizeKeepsText(t *testing.T) {
+	line := `x1 := 'ä' + "unterminated`
+	tokens, _ := golang.Tokenize(line, State{})
+	var text string
+	for _, token := range tokens {
+		text += token.Text
+	}
+	if text != line {
+		t.Errorf("Expected tokens to cover the line, got %q", text)
+	}
+	if last := tokens[len(tokens)-1]; last.Kind != String {
+		t.Errorf("Expected unterminated string at the end, got %+v", last)
+	}
+}
+
+func TestTokenizeMultilineState(t *testing.T) {
+	var (
+		state  State
+		tokens []Token
+	)
+	lines := []string{"a /* start", "middle", "end */ b"}
+	expect := [][]Token{
+		{{Kind: Plain, Text: "a "}, {Kind: Comment, Text: "/* start"}},
+		{{Kind: Comment, Text: "middle"}},
+		{{Kind: Comment, Text: "end */"}, {Kind: Plain, Text: " b"}},
+	}
+	for i, line := range lines {
+		tokens, state = cLike.Tokenize(line, state)
+		if !reflect.DeepEqual(tokens, expect[i]) {
+			t.Errorf("Line %d: got tokens %+v", i, tokens)
+		}
+	}
+
+	tokens, state = python.Tokenize(`x = """doc`, State{})
+	if tokens[len(tokens)-1].Kind != String {
+		t.Errorf("Expected string, got %+v", tokens)
+	}
+	tokens, _ = python.Tokenize(`more""" if`, state)
+	expectPython := []Token{{Kind: String, Text: `more"""`}, {Kind: Plain, Text: " "}, {Kind: Keyword, Text: "if"}}
+	if !reflect.DeepEqual(tokens, expectPython) {
+		t.Errorf("Got tokens %+v", tokens)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path      string
+		firstLine string
+		expect    string
+	}{
+		{path: "cmd/main.go", expect: "go"},
+		{path: "web/App.TSX", expect: "javascript"},
+		{path: "Makefile", expect: "make"},
+		{path: "bin/run", firstLine: "#!/usr/bin/env python3", expect: "python"},
+		{path: "bin/build", firstLine: "#!/bin/bash -e", expect: "shell"},
+		{path: "README", firstLine: "# Title", expect: ""},
+	}
+	for _, test := range tests {
+		language, ok := Detect(test.path, test.firstLine)
+		var name string
+		if ok {
+			name = language.Name
+		}
+		if name != test.expect {
+			t.Errorf("Detect(%q, %q) = %q, expected %q", test.path, test.firstLine, name, test.expect)
+		}
+	}
+}
@@ -1,14 +1,22 @@
 package textwrap
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 type LineRenderer func(line string) Renderer
 
+// IndexedLineRenderer returns the renderer of the line at the index of the text.
+type IndexedLineRenderer func(index int, line string) Renderer
+
 type Builder struct {
 	rawText      string
 	lines        []string
-	lineRenderer LineRenderer
+	lineRenderer IndexedLineRenderer
 	lineLength   int
+	// rowCounts caches the number of rows of each wrapped line, or 0 if it isn't known yet.
+	rowCounts []int
 }
 
 func NewBuilder() *Builder {
@@ -18,16 +26,25 @@ func NewBuilder() *Builder {
 }
 
 func (b *Builder) SetLineLength(lineLength int) {
+	if lineLength != b.lineLength {
+		b.rowCounts = nil
+	}
 	b.lineLength = lineLength
 }
 
 func (b *Builder) SetLineRenderer(handler LineRenderer) {
+	b.lineRenderer = func(_ int, line string) Renderer { return handler(line) }
+}
+
+// SetIndexedLineRenderer sets a renderer, which depends on the position of the line.
+func (b *Builder) SetIndexedLineRenderer(handler IndexedLineRenderer) {
 	b.lineRenderer = handler
 }
 
 func (b *Builder) WriteString(s string) {
 	b.rawText = s
 	b.lines = strings.Split(normalizedText(s), "\n")
+	b.rowCounts = nil
 }
 
 func (b *Builder) RawString() string {
@@ -35,35 +52,64 @@ func (b *Builder) RawString() string {
 }
 
 func (b *Builder) String() string {
-	var (
-		stringBuilder strings.Builder
-		wrapper       = NewWordWrapper(b.lineLength)
-	)
+	return strings.Join(b.Lines(), "\n")
+}
 
-	for i, l := range b.lines {
-		wrapper := wrapper
-		defer wrapper.Reset()
+// Lines returns each line of the text wrapped and rendered.
+// A wrapped line contains the line breaks inserted by wrapping.
+func (b *Builder) Lines() []string {
+	lines := make([]string, 0, len(b.lines))
+	for i := range b.lines {
+		lines = append(lines, b.Line(i))
+	}
+	return lines
+}
 
-		if renderer := b.lineRenderer(l); renderer != nil {
-			wrapper.SetRenderer(renderer)
-		}
+// LineCount returns the number of lines of the text before wrapping.
+func (b *Builder) LineCount() int {
+	return len(b.lines)
+}
+
+// Line returns the line at the index wrapped and rendered, e.g. to only render the visible lines.
+func (b *Builder) Line(index int) string {
+	var (
+		line    = b.lines[index]
+		wrapper = NewWordWrapper(b.lineLength)
+	)
+	if renderer := b.lineRenderer(index, line); renderer != nil {
+		wrapper.SetRenderer(renderer)
+	}
+	wrapper.WriteString(line)
+	return wrapper.String()
+}
 
-		wrapper.WriteString(l)
+// Rows returns the number of rows of the line at the index after wrapping.
+// Only lines longer than the line length are wrapped to count their rows.
+func (b *Builder) Rows(index int) int {
+	line := b.lines[index]
+	if utf8.RuneCountInString(line) <= b.lineLength {
+		return 1
+	}
 
-		stringBuilder.WriteString(wrapper.String())
-		if i == len(b.lines)-1 {
-			continue
-		}
-		stringBuilder.WriteString("\n")
+	if b.rowCounts == nil {
+		b.rowCounts = make([]int, len(b.lines))
+	}
+	if b.rowCounts[index] == 0 {
+		wrapper := NewWordWrapper(b.lineLength)
+		wrapper.WriteString(line)
+		b.rowCounts[index] = strings.Count(wrapper.String(), "\n") + 1
 	}
-	return stringBuilder.String()
+	return b.rowCounts[index]
 }
 
-func defaultLineRenderer() LineRenderer {
-	return func(line string) Renderer { return &Passthrough{} }
+func defaultLineRenderer() IndexedLineRenderer {
+	return func(_ int, line string) Renderer { return &Passthrough{} }
 }
 
+// normalizedText breaks lines at carriage returns, except for Windows line endings,
+// which are a single break, and expands tabs.
 func normalizedText(rawText string) string {
-	out := strings.ReplaceAll(rawText, "\r", "\n")
+	out := strings.ReplaceAll(rawText, "\r\n", "\n")
+	out = strings.ReplaceAll(out, "\r", "\n")
 	return strings.ReplaceAll(out, "\t", "    ")
 }
@@ -1,6 +1,8 @@
 package textwrap
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +41,145 @@ func TestBuilderWriteMultiLineWithTab(t *testing.T) {
 		t.Errorf("[%s] is not equal to [%s]", expect, got)
 	}
 }
+
+func TestBuilderWriteCRLF(t *testing.T) {
+	var (
+		text    = "one\r\ntwo\rthree\r\n"
+		builder = NewBuilder()
+
+		expect = "one\ntwo\nthree\n"
+		got    string
+	)
+
+	builder.SetLineLength(20)
+	builder.WriteString(text)
+	got = builder.String()
+
+	if expect != got {
+		t.Errorf("[%q] is not equal to [%q]", expect, got)
+	}
+	if count := builder.LineCount(); count != 4 {
+		t.Errorf("Expected 4 lines, got %d", count)
+	}
+}
+
+func TestBuilderLines(t *testing.T) {
+	var (
+		text    = "01234567\nend"
+		builder = NewBuilder()
+
+		expect = []string{"01234\n567", "[1]end"}
+	)
+
+	builder.SetLineLength(5)
+	builder.SetIndexedLineRenderer(func(index int, line string) Renderer {
+		if index == 0 {
+			return nil
+		}
+		return prefixRenderer{prefix: "[1]"}
+	})
+	builder.WriteString(text)
+	got := builder.Lines()
+
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("%q is not equal to %q", expect, got)
+	}
+}
+
+type prefixRenderer struct {
+	prefix string
+}
+
+func (r prefixRenderer) Render(s ...string) string {
+	return r.prefix + strings.Join(s, "")
+}
+
+func TestBuilderLineAndRows(t *testing.T) {
+	var (
+		text    = "01234567\nend\n12 34 567 89"
+		builder = NewBuilder()
+	)
+
+	builder.SetLineLength(5)
+	builder.SetIndexedLineRenderer(func(index int, line string) Renderer {
+		return prefixRenderer{prefix: ">"}
+	})
+	builder.WriteString(text)
+
+	if count := builder.LineCount(); count != 3 {
+		t.Errorf("LineCount is %d, expected 3", count)
+	}
+	for i, expect := range []int{2, 1, 3} {
+		if rows := builder.Rows(i); rows != expect {
+			t.Errorf("Rows(%d) is %d, expected %d", i, rows, expect)
+		}
+	}
+	if line := builder.Line(2); line != ">12 34\n 567 \n89" {
+		t.Errorf("Line(2) is %q", line)
+	}
+
+	// The cached rows are counted again for another line length.
+	builder.SetLineLength(20)
+	if rows := builder.Rows(2); rows != 1 {
+		t.Errorf("Rows(2) is %d after resize, expected 1", rows)
+	}
+}
+
+// benchmarkText is a diff like text with long lines between short ones.
+func benchmarkText(lines int) string {
+	var builder strings.Builder
+	for i := range lines {
+		if i%10 == 0 {
+			builder.WriteString("+\tfmt.Println(\"a long line, which needs to be wrapped, as it is longer than the view\", value, err)\n")
+		} else {
+			builder.WriteString(" \treturn err\n")
+		}
+	}
+	return builder.String()
+}
+
+// styleRenderer styles every rune like a syntax highlighted line.
+type styleRenderer struct{}
+
+func (styleRenderer) Render(s ...string) string {
+	var builder strings.Builder
+	for _, r := range strings.Join(s, "") {
+		builder.WriteString("\x1b[38;5;170m")
+		builder.WriteRune(r)
+		builder.WriteString("\x1b[0m")
+	}
+	return builder.String()
+}
+
+func newBenchmarkBuilder() *Builder {
+	builder := NewBuilder()
+	builder.SetLineLength(60)
+	builder.SetLineRenderer(func(line string) Renderer { return styleRenderer{} })
+	builder.WriteString(benchmarkText(50000))
+	return builder
+}
+
+// BenchmarkBuilderString renders all lines, like the diff did before it was virtualized.
+func BenchmarkBuilderString(b *testing.B) {
+	builder := newBenchmarkBuilder()
+	for b.Loop() {
+		_ = builder.String()
+	}
+}
+
+// BenchmarkBuilderVisibleLines lays out all lines, but only renders the lines of a view of 50 rows.
+func BenchmarkBuilderVisibleLines(b *testing.B) {
+	builder := newBenchmarkBuilder()
+	for b.Loop() {
+		builder.SetLineLength(59)
+		builder.SetLineLength(60)
+
+		var layout Layout
+		for i := range builder.LineCount() {
+			layout.Append(builder.Rows(i))
+		}
+		for block := layout.Block(25000); block < layout.Len() && layout.Start(block) < 25050; block++ {
+			_ = builder.Line(block)
+		}
+	}
+}
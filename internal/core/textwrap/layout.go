@@ -0,0 +1,38 @@
+package textwrap
+
+import "sort"
+
+// Layout positions blocks of rows below each other, e.g. wrapped lines.
+// It finds the blocks in a range of rows, so that only the visible blocks
+// of a long text need to be rendered.
+type Layout struct {
+	starts []int
+	rows   int
+}
+
+// Append adds a block of rows below the last block.
+func (l *Layout) Append(rows int) {
+	l.starts = append(l.starts, l.rows)
+	l.rows += rows
+}
+
+// Len returns the number of blocks.
+func (l Layout) Len() int {
+	return len(l.starts)
+}
+
+// Rows returns the number of rows of all blocks.
+func (l Layout) Rows() int {
+	return l.rows
+}
+
+// Start returns the first row of the block.
+func (l Layout) Start(block int) int {
+	return l.starts[block]
+}
+
+// Block returns the block, which contains the row. Rows after the last block
+// are contained by the last block.
+func (l Layout) Block(row int) int {
+	return max(sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > row })-1, 0)
+}
@@ -0,0 +1,25 @@
+package textwrap
+
+import "testing"
+
+func TestLayout(t *testing.T) {
+	var layout Layout
+	for _, rows := range []int{1, 3, 1, 2} {
+		layout.Append(rows)
+	}
+
+	if layout.Len() != 4 || layout.Rows() != 7 {
+		t.Errorf("Layout has %d blocks and %d rows, expected 4 and 7", layout.Len(), layout.Rows())
+	}
+	if start := layout.Start(3); start != 5 {
+		t.Errorf("Start(3) is %d, expected 5", start)
+	}
+	for row, expect := range []int{0, 1, 1, 1, 2, 3, 3, 3} {
+		if block := layout.Block(row); block != expect {
+			t.Errorf("Block(%d) is %d, expected %d", row, block, expect)
+		}
+	}
+	if block := (Layout{}).Block(0); block != 0 {
+		t.Errorf("Block of an empty layout is %d, expected 0", block)
+	}
+}
@@ -0,0 +1,13 @@
+package textwrap
+
+// Truncate shortens the text to at most width runes. Truncated text ends with `…`.
+func Truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,23 @@
+package textwrap
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text   string
+		width  int
+		expect string
+	}{
+		{text: "Jane Doe", width: 10, expect: "Jane Doe"},
+		{text: "Jane Doe", width: 8, expect: "Jane Doe"},
+		{text: "Jane Doe", width: 5, expect: "Jane…"},
+		{text: "Jürgen Müller", width: 8, expect: "Jürgen …"},
+		{text: "Jane Doe", width: 0, expect: ""},
+	}
+
+	for _, test := range tests {
+		if got := Truncate(test.text, test.width); got != test.expect {
+			t.Errorf("Truncate(%q, %d) is [%s], expected [%s]", test.text, test.width, got, test.expect)
+		}
+	}
+}
@@ -98,6 +98,12 @@ func (m Model) SetSize(width, height int) Model {
 	return m
 }
 
+// IsCapturingInput is false for contents that never capture input.
+func (m Model) IsCapturingInput() bool {
+	capturer, ok := m.content.(interface{ IsCapturingInput() bool })
+	return ok && capturer.IsCapturingInput()
+}
+
 func (m Model) Content() Content {
 	return m.content
 }
@@ -15,6 +15,20 @@ type ShowMsg struct {
 	Dialog Model
 }
 
+// Replace creates a tea.Cmd to show a dialog with the given content in place of
+// the top most dialog. The close command of the replaced dialog is not executed.
+func Replace(content Content, onCloseCmd tea.Cmd, displayMode DisplayMode) tea.Cmd {
+	return func() tea.Msg {
+		return ReplaceMsg{
+			Dialog: New(content, onCloseCmd, displayMode),
+		}
+	}
+}
+
+type ReplaceMsg struct {
+	Dialog Model
+}
+
 func Close() tea.Msg {
 	return CloseMsg{}
 }
@@ -13,3 +13,9 @@ type Content interface {
 	SetSize(width, height int) Content
 	Help() []key.Binding
 }
+
+// InputCapturer is implemented by contents that temporarily consume all keys,
+// e.g. while typing a filter. Esc is passed to the content instead of closing the dialog.
+type InputCapturer interface {
+	IsCapturingInput() bool
+}
@@ -45,7 +45,7 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.Type == tea.KeyEsc {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.Type == tea.KeyEsc && !m.isContentCapturingInput() {
 		return m, tea.Sequence(m.onCloseCmd, Close)
 	}
 
@@ -59,6 +59,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) isContentCapturingInput() bool {
+	capturer, ok := m.content.(InputCapturer)
+	return ok && capturer.IsCapturingInput()
+}
+
 func (m Model) View() string {
 	content := lipgloss.Place(
 		m.width, m.height-helpHeight,
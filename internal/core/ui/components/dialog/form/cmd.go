@@ -0,0 +1,31 @@
+package form
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/err"
+)
+
+type submitExecutedMsg struct {
+	errMsg err.Msg
+}
+
+func (s submitExecutedMsg) isSuccess() bool {
+	return s.errMsg == nil || (s.errMsg != nil && s.errMsg.Err() == nil)
+}
+
+func executeSubmitCmd(submitCmd tea.Cmd) tea.Cmd {
+	var msg tea.Msg
+	if submitCmd != nil {
+		msg = submitCmd()
+	}
+	executedMsg := submitExecutedMsg{}
+
+	if errMsg, ok := msg.(err.Msg); ok && errMsg.Err() != nil {
+		executedMsg.errMsg = errMsg
+	}
+
+	return tea.Sequence(
+		func() tea.Msg { return msg },
+		func() tea.Msg { return executedMsg },
+	)
+}
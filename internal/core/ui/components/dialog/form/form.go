@@ -0,0 +1,311 @@
+// Package form provides ui to enter multiple values, using
+// text fields, checkboxes and choices, before confirming an action.
+package form
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/label"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+)
+
+const (
+	titleHeight        = 1
+	borderPadding  int = 1
+	messagePadding int = 1
+	borderWidth    int = 1
+)
+
+var (
+	titleStyle        = style.Title.Height(titleHeight)
+	borderStyle       = style.FocusBorder.PaddingLeft(borderPadding).PaddingRight(borderPadding)
+	messageStyle      = style.Text.PaddingBottom(messagePadding)
+	fieldLabelStyle   = style.SublteText
+	focusedLabelStyle = style.FocusText
+	fieldStyle        = style.Text.PaddingBottom(1)
+)
+
+type fieldType byte
+
+const (
+	textFieldType fieldType = iota
+	checkboxFieldType
+	choiceFieldType
+)
+
+type field struct {
+	key       string
+	label     string
+	fieldType fieldType
+	input     textinput.Model
+	isChecked bool
+	options   []string
+	selected  int
+}
+
+// Model is a form with text fields, checkboxes and choices.
+// The fields are shown in the order they were added.
+type Model struct {
+	title        string
+	messageLabel label.MultiLine
+	fields       []field
+	focusIdx     int
+
+	onSubmit func(Values) tea.Cmd
+
+	keys KeyMap
+
+	width, maxContentWidth   int
+	height, maxContentHeight int
+}
+
+func New(title string, message string) Model {
+	return Model{
+		title:        title,
+		messageLabel: label.NewDefaultMultiLine().SetText(message),
+		keys:         NewKeyMap(),
+	}
+}
+
+// WithTextField adds a single line text field.
+func (m Model) WithTextField(key, label, placeholder, value string) Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.Prompt = ""
+	input.SetValue(value)
+	m.fields = append(m.fields, field{key: key, label: label, fieldType: textFieldType, input: input})
+	return m.focus(m.focusIdx)
+}
+
+// WithCheckbox adds a checkbox that can be toggled with space.
+func (m Model) WithCheckbox(key, label string, isChecked bool) Model {
+	m.fields = append(m.fields, field{key: key, label: label, fieldType: checkboxFieldType, isChecked: isChecked})
+	return m.focus(m.focusIdx)
+}
+
+// WithChoice adds a choice of one of the options, which can be changed with left and right.
+func (m Model) WithChoice(key, label string, options []string, selected int) Model {
+	m.fields = append(m.fields, field{key: key, label: label, fieldType: choiceFieldType, options: options, selected: selected})
+	return m.focus(m.focusIdx)
+}
+
+// WithOnSubmit sets the cmd to execute once the form is confirmed.
+func (m Model) WithOnSubmit(onSubmit func(Values) tea.Cmd) Model {
+	m.onSubmit = onSubmit
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m.updateFocusedField(msg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.confirm):
+		var submitCmd tea.Cmd
+		if m.onSubmit != nil {
+			submitCmd = m.onSubmit(m.Values())
+		}
+		return m, executeSubmitCmd(submitCmd)
+	case key.Matches(keyMsg, m.keys.next):
+		return m.focus(m.focusIdx + 1), nil
+	case key.Matches(keyMsg, m.keys.prev):
+		return m.focus(m.focusIdx - 1), nil
+	case key.Matches(keyMsg, m.keys.toggle) && m.isCheckboxFocused():
+		m.fields[m.focusIdx].isChecked = !m.fields[m.focusIdx].isChecked
+		return m, nil
+	case key.Matches(keyMsg, m.keys.prevOption) && m.isChoiceFocused():
+		return m.selectOption(-1), nil
+	case key.Matches(keyMsg, m.keys.nextOption) && m.isChoiceFocused():
+		return m.selectOption(1), nil
+	}
+
+	return m.updateFocusedField(msg)
+}
+
+func (m Model) View() string {
+	elements := []string{titleStyle.Render(m.title), ""}
+	if message := m.messageLabel.View(); len(message) > 0 {
+		elements = append(elements, messageStyle.Render(message))
+	}
+
+	for i, f := range m.fields {
+		labelStyle := fieldLabelStyle
+		if i == m.focusIdx {
+			labelStyle = focusedLabelStyle
+		}
+
+		switch f.fieldType {
+		case textFieldType:
+			elements = append(
+				elements,
+				labelStyle.Render(f.label),
+				fieldStyle.Render(f.input.View()),
+			)
+		case checkboxFieldType:
+			checkmark := " "
+			if f.isChecked {
+				checkmark = "x"
+			}
+			elements = append(elements, labelStyle.Render(fmt.Sprintf("[%s] %s", checkmark, f.label)))
+		case choiceFieldType:
+			options := make([]string, len(f.options))
+			for j, option := range f.options {
+				mark := " "
+				if j == f.selected {
+					mark = "•"
+				}
+				options[j] = fmt.Sprintf("(%s) %s", mark, option)
+			}
+			elements = append(
+				elements,
+				labelStyle.Render(f.label),
+				fieldStyle.Render(joinWrapped(options, "  ", m.maxContentWidth)),
+			)
+		}
+	}
+
+	content := lipgloss.NewStyle().
+		MaxWidth(m.maxContentWidth).
+		MaxHeight(m.maxContentHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, elements...))
+
+	return borderStyle.Render(content)
+}
+
+func (m Model) Help() []key.Binding {
+	keys := []key.Binding{m.keys.next, m.keys.prev}
+	if m.isCheckboxFocused() {
+		keys = append(keys, m.keys.toggle)
+	}
+	if m.isChoiceFocused() {
+		keys = append(keys, m.keys.prevOption, m.keys.nextOption)
+	}
+	return append(keys, m.keys.confirm, m.keys.cancel)
+}
+
+func (m Model) SetSize(width, height int) Model {
+	m.width = width - 2
+	m.height = height - 2
+
+	borderSize := 2*borderWidth + 2*borderPadding
+	m.maxContentWidth = m.width - borderSize
+	m.maxContentHeight = m.height - borderSize
+
+	m.messageLabel = m.messageLabel.SetWidth(m.maxContentWidth)
+	for i := range m.fields {
+		m.fields[i].input.Width = m.maxContentWidth - 1
+	}
+
+	return m
+}
+
+// Values returns the current values of all fields.
+func (m Model) Values() Values {
+	values := Values{
+		texts:   map[string]string{},
+		checked: map[string]bool{},
+		choices: map[string]int{},
+	}
+	for _, f := range m.fields {
+		switch f.fieldType {
+		case textFieldType:
+			values.texts[f.key] = f.input.Value()
+		case checkboxFieldType:
+			values.checked[f.key] = f.isChecked
+		case choiceFieldType:
+			values.choices[f.key] = f.selected
+		}
+	}
+	return values
+}
+
+func (m Model) isCheckboxFocused() bool {
+	return m.focusIdx < len(m.fields) && m.fields[m.focusIdx].fieldType == checkboxFieldType
+}
+
+func (m Model) isChoiceFocused() bool {
+	return m.focusIdx < len(m.fields) && m.fields[m.focusIdx].fieldType == choiceFieldType
+}
+
+// selectOption moves the selection of the focused choice by offset and wraps around.
+func (m Model) selectOption(offset int) Model {
+	fields := make([]field, len(m.fields))
+	copy(fields, m.fields)
+
+	f := &fields[m.focusIdx]
+	if len(f.options) > 0 {
+		f.selected = (f.selected + offset + len(f.options)) % len(f.options)
+	}
+	m.fields = fields
+	return m
+}
+
+func (m Model) focus(idx int) Model {
+	if len(m.fields) == 0 {
+		return m
+	}
+	idx = (idx + len(m.fields)) % len(m.fields)
+
+	fields := make([]field, len(m.fields))
+	copy(fields, m.fields)
+	for i := range fields {
+		if fields[i].fieldType != textFieldType {
+			continue
+		}
+		if i == idx {
+			fields[i].input.Focus()
+		} else {
+			fields[i].input.Blur()
+		}
+	}
+
+	m.fields = fields
+	m.focusIdx = idx
+	return m
+}
+
+func (m Model) updateFocusedField(msg tea.Msg) (Model, tea.Cmd) {
+	if m.focusIdx >= len(m.fields) || m.fields[m.focusIdx].fieldType != textFieldType {
+		return m, nil
+	}
+
+	fields := make([]field, len(m.fields))
+	copy(fields, m.fields)
+
+	input, cmd := fields[m.focusIdx].input.Update(msg)
+	fields[m.focusIdx].input = input
+	m.fields = fields
+	return m, cmd
+}
+
+// joinWrapped joins the elements with the separator and starts
+// a new line instead, if the next element exceeds the width.
+func joinWrapped(elements []string, separator string, width int) string {
+	var (
+		lines []string
+		line  string
+	)
+	for _, element := range elements {
+		switch {
+		case len(line) == 0:
+			line = element
+		case lipgloss.Width(line+separator+element) > width:
+			lines = append(lines, line)
+			line = element
+		default:
+			line += separator + element
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
@@ -0,0 +1,49 @@
+package form
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type KeyMap struct {
+	next       key.Binding
+	prev       key.Binding
+	toggle     key.Binding
+	prevOption key.Binding
+	nextOption key.Binding
+	confirm    key.Binding
+	cancel     key.Binding
+}
+
+func NewKeyMap() KeyMap {
+	return KeyMap{
+		next: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("⇥/↓", "next"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("⇧+⇥/↑", "previous"),
+		),
+		toggle: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "toggle"),
+		),
+		prevOption: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "previous option"),
+		),
+		nextOption: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "next option"),
+		),
+		confirm: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "confirm"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys(tea.KeyEsc.String()),
+			key.WithHelp("ESC", "cancel"),
+		),
+	}
+}
@@ -0,0 +1,23 @@
+package form
+
+// Values contains the values of all fields of a form by their key.
+type Values struct {
+	texts   map[string]string
+	checked map[string]bool
+	choices map[string]int
+}
+
+// Text returns the value of the text field with the given key.
+func (v Values) Text(key string) string {
+	return v.texts[key]
+}
+
+// IsChecked returns if the checkbox with the given key is checked.
+func (v Values) IsChecked(key string) bool {
+	return v.checked[key]
+}
+
+// Choice returns the index of the selected option of the choice with the given key.
+func (v Values) Choice(key string) int {
+	return v.choices[key]
+}
@@ -0,0 +1,102 @@
+// Package listdialog shows a list with a title in a dialog, e.g. the tags or the work trees.
+package listdialog
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+)
+
+const (
+	titleHeight   = 1
+	borderPadding = 1
+	borderWidth   = 1
+)
+
+var (
+	titleStyle  = style.Title.Height(titleHeight)
+	borderStyle = style.FocusBorder.PaddingLeft(borderPadding).PaddingRight(borderPadding)
+)
+
+// Model is a list, which is shown in the dialog.
+type Model[M any] interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (M, tea.Cmd)
+	UpdateFocus(isFocused bool) (M, tea.Cmd)
+	View() string
+	SetSize(width, height int) M
+	Title() string
+	IsReady() bool
+	IsEmpty() bool
+	IsFiltering() bool
+	Help() []key.Binding
+}
+
+type DialogContent[M Model[M]] struct {
+	model M
+	// emptyText is shown instead of an empty list.
+	emptyText     string
+	width, height int
+}
+
+func NewDialogContent[M Model[M]](model M, emptyText string) DialogContent[M] {
+	model, _ = model.UpdateFocus(true)
+	return DialogContent[M]{model: model, emptyText: emptyText}
+}
+
+func (dc DialogContent[M]) Init() tea.Cmd {
+	return dc.model.Init()
+}
+
+func (dc DialogContent[M]) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
+	model, cmd := dc.model.Update(msg)
+	dc.model = model
+	return dc, cmd
+}
+
+func (dc DialogContent[M]) View() string {
+	if !dc.model.IsReady() {
+		return ""
+	}
+
+	title := titleStyle.Render(dc.model.Title())
+
+	var listView string
+	if dc.model.IsEmpty() {
+		listView = dc.emptyText
+	} else {
+		listView = dc.model.View()
+	}
+
+	return borderStyle.
+		MaxHeight(dc.height).
+		Width(dc.width).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				title,
+				"",
+				listView,
+			),
+		)
+}
+
+func (dc DialogContent[M]) SetSize(width, height int) dialog.Content {
+	dc.width, dc.height = width, height
+
+	maxContentHeight := height - titleHeight - 1 - borderPadding*2 - borderWidth*2
+	maxContentWidth := width - borderPadding*2 - borderWidth*2
+	dc.model = dc.model.SetSize(maxContentWidth, maxContentHeight)
+	return dc
+}
+
+func (dc DialogContent[M]) Help() []key.Binding {
+	return dc.model.Help()
+}
+
+// IsCapturingInput reports whether the filter of the list is being typed.
+func (dc DialogContent[M]) IsCapturingInput() bool {
+	return dc.model.IsFiltering()
+}
@@ -0,0 +1,40 @@
+package progress
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// updatesBufferSize is the number of progress updates that can be queued
+// before further updates are dropped.
+const updatesBufferSize = 64
+
+// Operation is a long running task. It reports its progress line by line.
+type Operation func(report func(line string)) error
+
+type progressMsg struct {
+	updates chan tea.Msg
+	line    string
+}
+
+type finishedMsg struct {
+	updates chan tea.Msg
+	err     error
+}
+
+func run(operation Operation, updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		err := operation(func(line string) {
+			// Always keep the last slot for the finishedMsg. This way the operation never
+			// blocks, even if nobody receives the updates anymore.
+			if len(updates) < cap(updates)-1 {
+				updates <- progressMsg{updates: updates, line: line}
+			}
+		})
+		updates <- finishedMsg{updates: updates, err: err}
+		return nil
+	}
+}
+
+func waitForUpdate(updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
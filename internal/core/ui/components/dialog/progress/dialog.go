@@ -0,0 +1,55 @@
+package progress
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+)
+
+// DialogContent is a wrapper to use the progress ui as dialog.Content.
+// The dialog closes itself once the operation succeeded.
+// If the operation failed, the output stays visible until the dialog is closed.
+type DialogContent struct {
+	Model
+
+	closeKey key.Binding
+}
+
+func NewDialogContent(model Model) DialogContent {
+	return DialogContent{
+		Model: model,
+		closeKey: key.NewBinding(
+			key.WithKeys(tea.KeyEsc.String()),
+			key.WithHelp("ESC", "close"),
+		),
+	}
+}
+
+func (dc DialogContent) Init() tea.Cmd {
+	return dc.Model.Init()
+}
+
+func (dc DialogContent) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
+	wasFinished := dc.IsFinished()
+
+	m, cmd := dc.Model.Update(msg)
+	dc.Model = m
+
+	if !wasFinished && dc.IsFinished() && dc.Err() == nil {
+		return dc, dialog.Close
+	}
+	return dc, cmd
+}
+
+func (dc DialogContent) View() string {
+	return dc.Model.View()
+}
+
+func (dc DialogContent) SetSize(width, height int) dialog.Content {
+	dc.Model = dc.Model.SetSize(width, height)
+	return dc
+}
+
+func (dc DialogContent) Help() []key.Binding {
+	return []key.Binding{dc.closeKey}
+}
@@ -0,0 +1,159 @@
+// Package progress provides ui to run an operation while showing its progress output.
+package progress
+
+import (
+	"regexp"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+)
+
+const (
+	titleHeight       = 1
+	statusHeight      = 1
+	borderPadding int = 1
+	borderWidth   int = 1
+)
+
+var (
+	titleStyle   = style.Title.Height(titleHeight)
+	borderStyle  = style.FocusBorder.PaddingLeft(borderPadding).PaddingRight(borderPadding)
+	lineStyle    = style.Text
+	runningStyle = style.SublteText.Height(statusHeight)
+	failedStyle  = style.RemovedText.Height(statusHeight)
+
+	// percentageLineRegex matches progress updates like `Receiving objects:  50% (1/2)`.
+	percentageLineRegex = regexp.MustCompile(`^(.*?):\s+\d+%`)
+)
+
+// Model runs an Operation and shows the lines it reports.
+type Model struct {
+	title     string
+	operation Operation
+	updates   chan tea.Msg
+	lines     []string
+
+	err        error
+	isFinished bool
+
+	width, maxContentWidth   int
+	height, maxContentHeight int
+}
+
+func New(title string, operation Operation) Model {
+	return Model{
+		title:     title,
+		operation: operation,
+		updates:   make(chan tea.Msg, updatesBufferSize),
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		run(m.operation, m.updates),
+		waitForUpdate(m.updates),
+	)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		if msg.updates != m.updates {
+			return m, nil
+		}
+		m.lines = appendLine(m.lines, msg.line)
+		return m, waitForUpdate(m.updates)
+	case finishedMsg:
+		if msg.updates != m.updates {
+			return m, nil
+		}
+		m.isFinished = true
+		m.err = msg.err
+		if m.err != nil {
+			m.lines = append(m.lines, m.err.Error())
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var status string
+	if !m.isFinished {
+		status = runningStyle.Render("Running...")
+	} else if m.err != nil {
+		status = failedStyle.Render("Failed")
+	} else {
+		status = runningStyle.Render("Done")
+	}
+
+	maxLines := max(m.maxContentHeight-titleHeight-statusHeight-2, 0)
+	lines := m.lines
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+
+	renderedLines := make([]string, len(lines))
+	for i, line := range lines {
+		renderedLines[i] = lineStyle.MaxWidth(m.maxContentWidth).Render(line)
+	}
+
+	content := lipgloss.NewStyle().
+		MaxWidth(m.maxContentWidth).
+		MaxHeight(m.maxContentHeight).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				titleStyle.Render(m.title),
+				"",
+				lipgloss.JoinVertical(lipgloss.Left, renderedLines...),
+				"",
+				status,
+			),
+		)
+
+	return borderStyle.Width(m.width).Render(content)
+}
+
+func (m Model) Help() []key.Binding {
+	return []key.Binding{}
+}
+
+func (m Model) SetSize(width, height int) Model {
+	m.width = width - 2
+	m.height = height - 2
+
+	borderSize := 2*borderWidth + 2*borderPadding
+	m.maxContentWidth = m.width - borderSize
+	m.maxContentHeight = m.height - borderSize
+
+	return m
+}
+
+// IsFinished reports whether the operation has completed.
+func (m Model) IsFinished() bool {
+	return m.isFinished
+}
+
+// Err returns the error of the finished operation.
+func (m Model) Err() error {
+	return m.err
+}
+
+// appendLine appends line to lines. Percentage updates replace the
+// previous update of the same progress, e.g. `Receiving objects`.
+func appendLine(lines []string, line string) []string {
+	if len(lines) == 0 {
+		return append(lines, line)
+	}
+
+	last := lines[len(lines)-1]
+	lineMatch := percentageLineRegex.FindStringSubmatch(line)
+	lastMatch := percentageLineRegex.FindStringSubmatch(last)
+	if lineMatch != nil && lastMatch != nil && lineMatch[1] == lastMatch[1] {
+		lines[len(lines)-1] = line
+		return lines
+	}
+	return append(lines, line)
+}
@@ -35,3 +35,8 @@ func (c ContainerContent) SetSize(width, height int) container.Content {
 	c.Model = c.Model.SetSize(width, height)
 	return c
 }
+
+// IsCapturingInput reports whether the filter of the list is being typed.
+func (c ContainerContent) IsCapturingInput() bool {
+	return c.Model.IsFiltering()
+}
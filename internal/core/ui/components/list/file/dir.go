@@ -0,0 +1,93 @@
+package file
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/michaelhass/gitglance/internal/core/git"
+)
+
+const indentWidth = 2
+
+// DirItem is a directory of a file tree.
+type DirItem struct {
+	// Path of the directory, ending with a slash.
+	Path        string
+	Name        string
+	Depth       int
+	IsCollapsed bool
+	// Files contains all files below the directory.
+	Files git.FileStatusList
+	// Accessory shows the number of files by status code, e.g. `[M2 ?1]`.
+	Accessory string
+}
+
+// NewDirItem creates an item for a directory of a file tree. The status code of
+// each file, which is aggregated in the accessory, is returned by statusCode.
+func NewDirItem(
+	path string,
+	name string,
+	depth int,
+	isCollapsed bool,
+	files git.FileStatusList,
+	statusCode func(git.FileStatus) git.StatusCode,
+) DirItem {
+	return DirItem{
+		Path:        path,
+		Name:        name,
+		Depth:       depth,
+		IsCollapsed: isCollapsed,
+		Files:       files,
+		Accessory:   statusCounts(files, statusCode),
+	}
+}
+
+func (item DirItem) String() string {
+	return fmt.Sprintf("%s (%d files)", item.Path, len(item.Files))
+}
+
+func (item DirItem) Render() string {
+	marker := "▾"
+	if item.IsCollapsed {
+		marker = "▸"
+	}
+	return fmt.Sprintf("%s%s %s/ %s", indent(item.Depth), marker, item.Name, item.Accessory)
+}
+
+// ID identifies the directory by its path, which ends with a slash.
+func (item DirItem) ID() string {
+	return item.Path
+}
+
+// Paths returns the paths of all files below the directory.
+func (item DirItem) Paths() []string {
+	paths := make([]string, len(item.Files))
+	for i, file := range item.Files {
+		paths[i] = file.Path
+	}
+	return paths
+}
+
+func statusCounts(files git.FileStatusList, statusCode func(git.FileStatus) git.StatusCode) string {
+	counts := make(map[git.StatusCode]int)
+	for _, file := range files {
+		counts[statusCode(file)]++
+	}
+
+	codes := make([]git.StatusCode, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	descriptions := make([]string, len(codes))
+	for i, code := range codes {
+		descriptions[i] = fmt.Sprintf("%c%d", code, counts[code])
+	}
+	return fmt.Sprintf("[%s]", strings.Join(descriptions, " "))
+}
+
+func indent(depth int) string {
+	return strings.Repeat(" ", depth*indentWidth)
+}
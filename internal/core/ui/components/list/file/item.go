@@ -2,6 +2,7 @@ package file
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/michaelhass/gitglance/internal/core/git"
 )
@@ -9,6 +10,10 @@ import (
 type Item struct {
 	git.FileStatus
 	Accessory string
+	// Name and Depth are set for items of a file tree.
+	// Only the name is shown, indented by the depth.
+	Name  string
+	Depth int
 }
 
 func (item Item) String() string {
@@ -20,6 +25,14 @@ func (item Item) String() string {
 		path = item.Path
 	}
 
+	if item.IsSubmodule() {
+		path = fmt.Sprintf("%s%s", path, submoduleDescription(item.Submodule))
+	}
+
+	if !item.IgnoreRule.IsEmpty() {
+		path = fmt.Sprintf("%s (%s)", path, item.IgnoreRule)
+	}
+
 	if len(item.Accessory) == 0 {
 		return path
 	}
@@ -28,7 +41,20 @@ func (item Item) String() string {
 }
 
 func (item Item) Render() string {
-	return item.String()
+	if len(item.Name) == 0 {
+		return item.String()
+	}
+	return fmt.Sprintf("%s%s %s", indent(item.Depth), item.Accessory, item.Name)
+}
+
+// ID identifies the file by its path.
+func (item Item) ID() string {
+	return item.Path
+}
+
+// Kind groups files by their status code.
+func (item Item) Kind() string {
+	return item.Accessory
 }
 
 func NewItem(fileStatus git.FileStatus, accessory string) Item {
@@ -37,3 +63,29 @@ func NewItem(fileStatus git.FileStatus, accessory string) Item {
 		Accessory:  fmt.Sprintf("[%s]", accessory),
 	}
 }
+
+// NewTreeItem creates an item for a file of a file tree.
+func NewTreeItem(fileStatus git.FileStatus, accessory string, name string, depth int) Item {
+	item := NewItem(fileStatus, accessory)
+	item.Name = name
+	item.Depth = depth
+	return item
+}
+
+// submoduleDescription describes the changes of a submodule, e.g. ` (new commits, modified)`.
+func submoduleDescription(state git.SubmoduleStatus) string {
+	var changes []string
+	if state.HasNewCommits {
+		changes = append(changes, "new commits")
+	}
+	if state.HasModifiedContent {
+		changes = append(changes, "modified")
+	}
+	if state.HasUntrackedContent {
+		changes = append(changes, "untracked")
+	}
+	if len(changes) == 0 {
+		return " (submodule)"
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(changes, ", "))
+}
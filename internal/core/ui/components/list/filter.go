@@ -0,0 +1,92 @@
+package list
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/michaelhass/gitglance/internal/core/fuzzy"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/query"
+)
+
+// IsFiltering reports whether the filter is being typed.
+// All keys but the arrow keys are consumed by the filter.
+func (m Model) IsFiltering() bool {
+	return m.isFiltering
+}
+
+// IsFiltered reports whether only the items matching a filter are shown.
+func (m Model) IsFiltered() bool {
+	return len(m.filter) > 0
+}
+
+// updateFilter edits the filter while typing.
+func (m Model) updateFilter(msg tea.KeyMsg) (Model, tea.Cmd) {
+	filter, isFiltering := query.Edit(m.filter, msg)
+	m.isFiltering = isFiltering
+	if filter == m.filter {
+		return m, nil
+	}
+	return m.setFilter(filter)
+}
+
+// setFilter shows the items that match the filter and focuses the first of them.
+func (m Model) setFilter(filter string) (Model, tea.Cmd) {
+	m.filter = filter
+	m.items = filterItems(m.allItems, filter)
+	m.cursor = 0
+	m.pageStartIdx = 0
+	m.visibleItems = m.updateVisibleItems()
+
+	if len(m.visibleItems) == 0 {
+		m.lastFocusedIdx = notAvailableIdx
+		return m, m.itemHandler(NoItemsMsg{})
+	}
+	m.lastFocusedIdx = m.cursor
+	return m, m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+}
+
+// filterItems returns the items whose rendered text fuzzy matches the filter.
+func filterItems(items []Item, filter string) []Item {
+	if len(filter) == 0 {
+		return items
+	}
+
+	var filtered []Item
+	for _, item := range items {
+		if _, ok := fuzzy.Match(filter, ansi.Strip(item.Render())); ok {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// renderMatches renders the text with the style and highlights the characters that match the filter.
+func renderMatches(text string, filter string, style lipgloss.Style) string {
+	indices, _ := fuzzy.Match(filter, text)
+
+	isMatched := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		isMatched[i] = true
+	}
+
+	var (
+		builder    strings.Builder
+		runes      = []rune(text)
+		matchStyle = style.Underline(true).Bold(true)
+		start      = 0
+	)
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && isMatched[i] == isMatched[start] {
+			continue
+		}
+		segmentStyle := style
+		if isMatched[start] {
+			segmentStyle = matchStyle
+		}
+		builder.WriteString(segmentStyle.Render(string(runes[start:i])))
+		start = i
+	}
+	return builder.String()
+}
@@ -1,5 +1,29 @@
 package list
 
+import "github.com/charmbracelet/lipgloss"
+
 type Item interface {
 	Render() string
 }
+
+// StyledItem is an Item that applies the style of the list itself.
+// It allows to render parts of the item differently, e.g. in another color.
+type StyledItem interface {
+	Item
+	RenderWithStyle(style lipgloss.Style) string
+}
+
+// IdentifiableItem is an Item with an ID that stays the same when the items
+// of the list are set again, e.g. the path of a file. Marks are kept by ID.
+// Other items are identified by their rendered text.
+type IdentifiableItem interface {
+	Item
+	ID() string
+}
+
+// KindItem is an Item of a kind, e.g. a file with a status code.
+// All items of the kind of the focused item can be marked at once.
+type KindItem interface {
+	Item
+	Kind() string
+}
@@ -9,6 +9,12 @@ type KeyMap struct {
 	All        key.Binding
 	Edit       key.Binding
 	Delete     key.Binding
+	Filter     key.Binding
+	Mark       key.Binding
+	MarkUp     key.Binding
+	MarkDown   key.Binding
+	MarkKind   key.Binding
+	Unmark     key.Binding
 	CustomKeys []key.Binding
 }
 
@@ -38,12 +44,53 @@ func NewKeyMap(allText string, enterHelpText string, deleteHelpText string) KeyM
 			key.WithKeys("ctrl+d"),
 			key.WithHelp("ctrl+d", deleteHelpText),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+			key.WithDisabled(),
+		),
+		MarkUp: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("⇧+k", "mark up"),
+			key.WithDisabled(),
+		),
+		MarkDown: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("⇧+j", "mark down"),
+			key.WithDisabled(),
+		),
+		MarkKind: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "mark same kind"),
+			key.WithDisabled(),
+		),
+		Unmark: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "unmark all"),
+			key.WithDisabled(),
+		),
 		CustomKeys: []key.Binding{},
 	}
 }
 
+// WithMarks enables the keys to mark multiple items. Marked items are
+// passed to the item handler on select and delete. Unmark is enabled
+// by the list, while items are marked.
+func (km KeyMap) WithMarks(markKindHelpText string) KeyMap {
+	km.Mark.SetEnabled(true)
+	km.MarkUp.SetEnabled(true)
+	km.MarkDown.SetEnabled(true)
+	km.MarkKind.SetEnabled(true)
+	km.MarkKind.SetHelp("*", markKindHelpText)
+	return km
+}
+
 func (km KeyMap) ShortHelp() []key.Binding {
-	var bindings = []key.Binding{km.Up, km.Down, km.All, km.Enter, km.Edit, km.Delete}
+	var bindings = []key.Binding{km.Up, km.Down, km.All, km.Enter, km.Edit, km.Delete, km.Filter, km.Mark, km.MarkUp, km.MarkDown, km.MarkKind, km.Unmark}
 	bindings = append(bindings, km.CustomKeys...)
 	return bindings
 }
@@ -51,3 +98,31 @@ func (km KeyMap) ShortHelp() []key.Binding {
 func (km KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{}
 }
+
+// filterKeyMap describes the keys while typing a filter.
+// All other keys are added to the filter.
+type filterKeyMap struct {
+	keep  key.Binding
+	clear key.Binding
+}
+
+func newFilterKeyMap() filterKeyMap {
+	return filterKeyMap{
+		keep: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("⏎", "keep filter"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+	}
+}
+
+func (km filterKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.keep, km.clear}
+}
+
+func (km filterKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{}
+}
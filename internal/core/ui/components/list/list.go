@@ -2,11 +2,13 @@ package list
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/michaelhass/gitglance/internal/core/ui/style"
 )
 
@@ -23,6 +25,9 @@ const (
 type ItemHandler func(msg tea.Msg) tea.Cmd
 
 type Model struct {
+	// allItems are all items of the list. items only contains
+	// the items that match the filter.
+	allItems       []Item
 	items          []Item
 	visibleItems   []Item
 	itemHandler    ItemHandler
@@ -34,6 +39,10 @@ type Model struct {
 	pageStartIdx   int
 	isFocused      bool
 	lastFocusedIdx int
+	filter         string
+	isFiltering    bool
+	// marked contains the IDs of the marked items.
+	marked map[string]bool
 }
 
 func New(title string, itemHandler ItemHandler, keys KeyMap) Model {
@@ -55,40 +64,46 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m = model
 		cmds = append(cmds, cmd)
 	case tea.KeyMsg:
+		// While typing a filter, the arrow keys still move the cursor.
+		if m.isFiltering && msg.Type != tea.KeyUp && msg.Type != tea.KeyDown {
+			model, cmd := m.updateFilter(msg)
+			m = model
+			cmds = append(cmds, cmd)
+			break
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Up):
-			if m.cursor == 0 || len(m.visibleItems) == 0 {
-				if m.IsFirstIndexFocused() {
-					cmds = append(cmds, m.itemHandler(TopNoMoreFocusableItems{}))
-				} else {
-					m.pageStartIdx = m.nextPageStartIdx(-1)
-					m.visibleItems = m.updateVisibleItems()
-				}
-				break
-			}
-			m.cursor -= 1
-			cmd := m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+			model, cmd := m.moveUp()
+			m = model
 			cmds = append(cmds, cmd)
 		case key.Matches(msg, m.keys.Down):
-			if m.cursor >= len(m.visibleItems)-1 {
-				if m.IsLastIndexFocused() {
-					cmds = append(cmds, m.itemHandler(BottomNoMoreFocusableItems{}))
-				} else {
-					m.pageStartIdx = m.nextPageStartIdx(1)
-					m.visibleItems = m.updateVisibleItems()
-				}
-				break
-			}
-			m.cursor += 1
-			cmd := m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+			model, cmd := m.moveDown()
+			m = model
+			cmds = append(cmds, cmd)
+		case key.Matches(msg, m.keys.Mark):
+			model, cmd := m.toggleMark()
+			m = model
 			cmds = append(cmds, cmd)
+		case key.Matches(msg, m.keys.MarkUp):
+			model, cmd := m.extendMarks(-1)
+			m = model
+			cmds = append(cmds, cmd)
+		case key.Matches(msg, m.keys.MarkDown):
+			model, cmd := m.extendMarks(1)
+			m = model
+			cmds = append(cmds, cmd)
+		case key.Matches(msg, m.keys.MarkKind):
+			m = m.toggleKindMarks()
+		case key.Matches(msg, m.keys.Unmark):
+			m = m.setMarks(false, m.MarkedItems()...)
 		case key.Matches(msg, m.keys.Enter):
 			if item, err := m.FocusedItem(); err == nil {
-				cmd := m.itemHandler(SelectItemMsg{Item: item})
+				cmd := m.itemHandler(SelectItemMsg{Item: item, Items: m.SelectedItems()})
 				cmds = append(cmds, cmd)
 			}
 		case key.Matches(msg, m.keys.All):
-			cmd := m.itemHandler(SelectAllItemMsg{Items: m.items})
+			cmd := m.itemHandler(SelectAllItemMsg{Items: m.items, IsFiltered: m.IsFiltered()})
 			cmds = append(cmds, cmd)
 		case key.Matches(msg, m.keys.Edit):
 			if item, err := m.FocusedItem(); err == nil {
@@ -97,7 +112,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 		case key.Matches(msg, m.keys.Delete):
 			if item, err := m.FocusedItem(); err == nil {
-				cmd := m.itemHandler(DeleteItemMsg{Item: item})
+				cmd := m.itemHandler(DeleteItemMsg{Item: item, Items: m.SelectedItems()})
 				cmds = append(cmds, cmd)
 			}
 		case key.Matches(msg, m.keys.CustomKeys...):
@@ -105,12 +120,40 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				cmd := m.itemHandler(CustomItemMsg{Item: item, KeyMsg: msg})
 				cmds = append(cmds, cmd)
 			}
+		case key.Matches(msg, m.keys.Filter):
+			m.isFiltering = true
 		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+func (m Model) moveUp() (Model, tea.Cmd) {
+	if m.cursor == 0 || len(m.visibleItems) == 0 {
+		if m.IsFirstIndexFocused() {
+			return m, m.itemHandler(TopNoMoreFocusableItems{})
+		}
+		m.pageStartIdx = m.nextPageStartIdx(-1)
+		m.visibleItems = m.updateVisibleItems()
+		return m, nil
+	}
+	m.cursor -= 1
+	return m, m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+}
+
+func (m Model) moveDown() (Model, tea.Cmd) {
+	if m.cursor >= len(m.visibleItems)-1 {
+		if m.IsLastIndexFocused() {
+			return m, m.itemHandler(BottomNoMoreFocusableItems{})
+		}
+		m.pageStartIdx = m.nextPageStartIdx(1)
+		m.visibleItems = m.updateVisibleItems()
+		return m, nil
+	}
+	m.cursor += 1
+	return m, m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+}
+
 func (m Model) UpdateFocus(isFocused bool) (Model, tea.Cmd) {
 	return m.updateFocus(isFocused, false)
 }
@@ -132,7 +175,10 @@ func (m Model) updateFocus(isFocused bool, isForced bool) (Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
-	var renderedItems = make([]string, len(m.visibleItems))
+	var (
+		renderedItems = make([]string, len(m.visibleItems))
+		hasMarks      = m.hasMarks()
+	)
 
 	for i, item := range m.visibleItems {
 		style := itemStyle
@@ -141,7 +187,14 @@ func (m Model) View() string {
 		} else if i == m.cursor {
 			style = focusedItemStyle
 		}
-		renderedItems[i] = style.MaxHeight(1).MaxWidth(m.width - 1).Render(item.Render())
+		content := m.renderItem(item, style)
+		if hasMarks {
+			content = style.Render(markGutter(m.isMarked(item))) + content
+		}
+		renderedItems[i] = lipgloss.NewStyle().
+			MaxHeight(1).
+			MaxWidth(m.width - 1).
+			Render(content)
 	}
 
 	return lipgloss.
@@ -150,8 +203,28 @@ func (m Model) View() string {
 		Render(lipgloss.JoinVertical(lipgloss.Top, renderedItems...))
 }
 
+func (m Model) renderItem(item Item, style lipgloss.Style) string {
+	if m.IsFiltered() {
+		return renderMatches(ansi.Strip(item.Render()), m.filter, style)
+	}
+	if styledItem, ok := item.(StyledItem); ok {
+		return styledItem.RenderWithStyle(style)
+	}
+	return style.Render(item.Render())
+}
+
+// Title returns the title of the list. A filter is appended with
+// the number of matching items, e.g. `Unstaged /main [2/10]`.
 func (m Model) Title() string {
-	return m.title
+	if !m.isFiltering && !m.IsFiltered() {
+		return m.title
+	}
+
+	var cursor string
+	if m.isFiltering {
+		cursor = "_"
+	}
+	return fmt.Sprintf("%s /%s%s [%d/%d]", m.title, m.filter, cursor, len(m.items), len(m.allItems))
 }
 
 func (m Model) SetTitle(title string) Model {
@@ -167,11 +240,22 @@ func (m Model) SetSize(width, height int) Model {
 }
 
 func (m Model) KeyMap() help.KeyMap {
+	if m.isFiltering {
+		return newFilterKeyMap()
+	}
 	return m.keys
 }
 
+// SetKeyMap replaces the keys of the list, e.g. to enable custom keys.
+func (m Model) SetKeyMap(keys KeyMap) Model {
+	m.keys = keys
+	m.keys.Unmark.SetEnabled(m.hasMarks())
+	return m
+}
+
+// ItemsCount returns the number of all items, regardless of the filter.
 func (m Model) ItemsCount() int {
-	return len(m.items)
+	return len(m.allItems)
 }
 
 func (m Model) IsEmpty() bool {
@@ -179,7 +263,12 @@ func (m Model) IsEmpty() bool {
 }
 
 func (m Model) SetItems(items []Item) (Model, tea.Cmd) {
-	m.items = items
+	m.allItems = items
+	m.items = filterItems(items, m.filter)
+	m.marked = m.remainingMarks()
+	m.keys.Unmark.SetEnabled(m.hasMarks())
+	// The page can't start behind the items, e.g. if much fewer items are set.
+	m.pageStartIdx = max(min(m.pageStartIdx, len(m.items)-m.pageSize()), 0)
 	m.visibleItems = m.updateVisibleItems()
 
 	// Check out of bounds due to content change
@@ -188,6 +277,7 @@ func (m Model) SetItems(items []Item) (Model, tea.Cmd) {
 		m.pageStartIdx = m.nextPageStartIdx(-1)
 		m.visibleItems = m.updateVisibleItems()
 	}
+	m.cursor = max(min(m.cursor, len(m.visibleItems)-1), 0)
 
 	if !m.isFocused || len(m.visibleItems) == 0 {
 		return m, nil
@@ -203,6 +293,34 @@ func (m Model) FocusedItem() (Item, error) {
 	return m.visibleItems[m.cursor], nil
 }
 
+// FocusedIndex returns the index of the focused item in the items matching the filter.
+func (m Model) FocusedIndex() int {
+	return m.pageStartIdx + m.cursor
+}
+
+// SetFocusedIndex focuses the item at the index of the items matching the filter,
+// e.g. to keep the position after the items were replaced. The index is clamped to the items.
+func (m Model) SetFocusedIndex(index int) (Model, tea.Cmd) {
+	if len(m.items) == 0 || m.pageSize() <= 0 {
+		return m, nil
+	}
+
+	index = max(min(index, len(m.items)-1), 0)
+	if index < m.pageStartIdx {
+		m.pageStartIdx = index
+	} else if index >= m.pageStartIdx+m.pageSize() {
+		m.pageStartIdx = index - m.pageSize() + 1
+	}
+	m.pageStartIdx = max(min(m.pageStartIdx, len(m.items)-m.pageSize()), 0)
+	m.cursor = index - m.pageStartIdx
+	m.visibleItems = m.updateVisibleItems()
+
+	if !m.isFocused || m.cursor >= len(m.visibleItems) {
+		return m, nil
+	}
+	return m, m.itemHandler(FocusItemMsg{Item: m.visibleItems[m.cursor]})
+}
+
 func (m Model) IsFirstIndexFocused() bool {
 	if len(m.items) == 0 {
 		return true
@@ -0,0 +1,154 @@
+package list
+
+import (
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	markedGutter   = "● "
+	unmarkedGutter = "  "
+)
+
+// MarkedItems returns the marked items in the order of the list, including
+// marked items that don't match the filter.
+func (m Model) MarkedItems() []Item {
+	var items []Item
+	for _, item := range m.allItems {
+		if m.isMarked(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SelectedItems returns the marked items or, if none is marked, the focused item.
+func (m Model) SelectedItems() []Item {
+	if items := m.MarkedItems(); len(items) > 0 {
+		return items
+	}
+	if item, err := m.FocusedItem(); err == nil {
+		return []Item{item}
+	}
+	return nil
+}
+
+func (m Model) isMarked(item Item) bool {
+	return m.marked[itemID(item)]
+}
+
+// toggleMark marks or unmarks the focused item and focuses the next one.
+func (m Model) toggleMark() (Model, tea.Cmd) {
+	item, err := m.FocusedItem()
+	if err != nil {
+		return m, nil
+	}
+	m = m.setMarks(!m.isMarked(item), item)
+	if m.IsLastIndexFocused() {
+		return m, nil
+	}
+	return m.moveDown()
+}
+
+// extendMarks marks the focused item and the item at the offset, which is focused then.
+func (m Model) extendMarks(offset int) (Model, tea.Cmd) {
+	item, err := m.FocusedItem()
+	if err != nil {
+		return m, nil
+	}
+	m = m.setMarks(true, item)
+
+	var cmd tea.Cmd
+	switch {
+	case offset < 0 && !m.IsFirstIndexFocused():
+		m, cmd = m.moveUp()
+	case offset > 0 && !m.IsLastIndexFocused():
+		m, cmd = m.moveDown()
+	default:
+		return m, nil
+	}
+
+	if item, err := m.FocusedItem(); err == nil {
+		m = m.setMarks(true, item)
+	}
+	return m, cmd
+}
+
+// toggleKindMarks marks all items of the kind of the focused item that match the filter.
+// If all of them are marked already, they are unmarked.
+func (m Model) toggleKindMarks() Model {
+	focusedItem, err := m.FocusedItem()
+	if err != nil {
+		return m
+	}
+	kindItem, ok := focusedItem.(KindItem)
+	if !ok {
+		return m
+	}
+
+	var (
+		items       []Item
+		isAllMarked = true
+	)
+	for _, item := range m.items {
+		if other, ok := item.(KindItem); ok && other.Kind() == kindItem.Kind() {
+			items = append(items, item)
+			isAllMarked = isAllMarked && m.isMarked(item)
+		}
+	}
+	return m.setMarks(!isAllMarked, items...)
+}
+
+// setMarks marks or unmarks the items. The marks are copied, as they are shared
+// with previous values of the model.
+func (m Model) setMarks(isMarked bool, items ...Item) Model {
+	marked := maps.Clone(m.marked)
+	if marked == nil {
+		marked = make(map[string]bool)
+	}
+	for _, item := range items {
+		if isMarked {
+			marked[itemID(item)] = true
+		} else {
+			delete(marked, itemID(item))
+		}
+	}
+	m.marked = marked
+	m.keys.Unmark.SetEnabled(m.hasMarks())
+	return m
+}
+
+// hasMarks reports whether items are marked and can be unmarked.
+func (m Model) hasMarks() bool {
+	return m.keys.Mark.Enabled() && len(m.marked) > 0
+}
+
+// remainingMarks returns the marks of the items that are still part of the list.
+func (m Model) remainingMarks() map[string]bool {
+	if len(m.marked) == 0 {
+		return nil
+	}
+	marked := make(map[string]bool)
+	for _, item := range m.allItems {
+		if id := itemID(item); m.marked[id] {
+			marked[id] = true
+		}
+	}
+	return marked
+}
+
+func itemID(item Item) string {
+	if identifiableItem, ok := item.(IdentifiableItem); ok {
+		return identifiableItem.ID()
+	}
+	return ansi.Strip(item.Render())
+}
+
+func markGutter(isMarked bool) string {
+	if isMarked {
+		return markedGutter
+	}
+	return unmarkedGutter
+}
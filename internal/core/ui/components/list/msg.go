@@ -21,6 +21,8 @@ func ForceFocusUpdate() tea.Msg {
 // `enter` key trigger.
 type SelectItemMsg struct {
 	Item Item
+	// Items are the marked items or, if none is marked, only the focused item.
+	Items []Item
 }
 
 // DeleteItemMsg indicates the intent to delete an item in the list.
@@ -28,11 +30,15 @@ type SelectItemMsg struct {
 // from the itemHandler to actually remove the data and update the views.
 type DeleteItemMsg struct {
 	Item Item
+	// Items are the marked items or, if none is marked, only the focused item.
+	Items []Item
 }
 
 // SelectAllItemMsg produced when all items in a list were selected.
+// If the list is filtered, only the items matching the filter are included.
 type SelectAllItemMsg struct {
-	Items []Item
+	Items      []Item
+	IsFiltered bool
 }
 
 // EditItemMsg is an intent to edit an item.
@@ -0,0 +1,24 @@
+// Package query provides the editing of a query, which is typed key by key, e.g. to filter a list.
+package query
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Edit returns the query edited by the key and whether it is still typed.
+// Enter keeps the query and esc clears it. Other keys don't change the query.
+func Edit(query string, msg tea.KeyMsg) (string, bool) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return query, false
+	case tea.KeyEsc:
+		return "", false
+	case tea.KeyBackspace:
+		if runes := []rune(query); len(runes) > 0 {
+			return string(runes[:len(runes)-1]), true
+		}
+	case tea.KeySpace:
+		return query + " ", true
+	case tea.KeyRunes:
+		return query + string(msg.Runes), true
+	}
+	return query, true
+}
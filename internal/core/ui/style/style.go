@@ -17,6 +17,30 @@ var (
 	AddedText         = lipgloss.NewStyle().Foreground(addedTextColor)
 	removedTextColor  = lipgloss.AdaptiveColor{Light: "ff6166", Dark: "#ff6961"}
 	RemovedText       = lipgloss.NewStyle().Foreground(removedTextColor)
+	hunkHeaderColor   = lipgloss.AdaptiveColor{Light: "#1e66f5", Dark: "#61afef"}
+	HunkHeaderText    = lipgloss.NewStyle().Foreground(hunkHeaderColor)
+
+	// Backgrounds tint added and removed lines, whose text is colored by syntax.
+	AddedBackgroundColor   = lipgloss.AdaptiveColor{Light: "#dafbe1", Dark: "#12301d"}
+	RemovedBackgroundColor = lipgloss.AdaptiveColor{Light: "#ffebe9", Dark: "#3c1618"}
+	// Emphasis backgrounds mark the changed words of modified lines.
+	AddedEmphasisBackgroundColor   = lipgloss.AdaptiveColor{Light: "#abf2bc", Dark: "#1f6b3a"}
+	RemovedEmphasisBackgroundColor = lipgloss.AdaptiveColor{Light: "#ffc1c0", Dark: "#7a2a2e"}
+
+	// Search matches are highlighted on top of all other colors.
+	searchMatchColor        = lipgloss.AdaptiveColor{Light: "#f9e2af", Dark: "#e5c07b"}
+	SearchMatch             = lipgloss.NewStyle().Background(searchMatchColor).Foreground(lipgloss.Color("#000000"))
+	currentSearchMatchColor = lipgloss.AdaptiveColor{Light: "#fe640b", Dark: "#ff9e3b"}
+	CurrentSearchMatch      = lipgloss.NewStyle().Background(currentSearchMatchColor).Foreground(lipgloss.Color("#000000"))
+
+	keywordTextColor = lipgloss.AdaptiveColor{Light: "#8839ef", Dark: "#c678dd"}
+	KeywordText      = lipgloss.NewStyle().Foreground(keywordTextColor)
+	stringTextColor  = lipgloss.AdaptiveColor{Light: "#0a7e8c", Dark: "#56b6c2"}
+	StringText       = lipgloss.NewStyle().Foreground(stringTextColor)
+	numberTextColor  = lipgloss.AdaptiveColor{Light: "#c4540b", Dark: "#d19a66"}
+	NumberText       = lipgloss.NewStyle().Foreground(numberTextColor)
+	commentTextColor = lipgloss.AdaptiveColor{Light: "#8c8fa1", Dark: "#7f848e"}
+	CommentText      = lipgloss.NewStyle().Foreground(commentTextColor)
 
 	titleBackgroundColor         = lipgloss.AdaptiveColor{Light: "#874BFD", Dark: "#7D56F4"}
 	Title                        = lipgloss.NewStyle().Padding(0, 1).Background(titleBackgroundColor)
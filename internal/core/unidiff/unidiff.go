@@ -0,0 +1,297 @@
+// Package unidiff parses the unified diff output of git into typed files, hunks and lines,
+// e.g. to render, navigate or stage parts of a diff.
+package unidiff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LineKind is the kind of a line of a diff.
+type LineKind byte
+
+const (
+	// Other lines are outside of files, e.g. the commit message of `git show`.
+	Other LineKind = iota
+	// FileHeader lines describe a file, e.g. `diff --git`, `index`, `---` and `+++`.
+	FileHeader
+	// HunkHeader lines start a hunk, e.g. `@@ -1,3 +1,4 @@ func main() {`.
+	HunkHeader
+	Context
+	Added
+	Deleted
+	// NoNewline lines mark the line above as missing a newline at the end of the file.
+	NoNewline
+	// Binary lines are the content of binary files, e.g. `Binary files a/x and b/x differ`.
+	Binary
+)
+
+// IsContent reports whether lines of the kind are content lines of a hunk.
+func (k LineKind) IsContent() bool {
+	return k == Context || k == Added || k == Deleted
+}
+
+// Line is a line of a diff.
+type Line struct {
+	Kind LineKind
+	// Index is the index of the line in the diff.
+	Index int
+	// Text is the line without the prefix of its kind, e.g. `+` for added lines.
+	Text string
+	// OldNumber and NewNumber are the line numbers in the old and new file, or 0 if the line isn't part of it.
+	OldNumber, NewNumber int
+}
+
+// Hunk is a range of changed lines of a file.
+type Hunk struct {
+	// Header is the index of the header line in the diff.
+	Header int
+	// OldStart and NewStart are the first line numbers of the hunk in the old and new file.
+	OldStart, NewStart int
+	// OldCount and NewCount are the number of lines of the hunk in the old and new file.
+	OldCount, NewCount int
+	// Section is the heading after the range, e.g. the enclosing function.
+	Section string
+	Lines   []Line
+}
+
+// End returns the index after the last line of the hunk in the diff.
+func (h Hunk) End() int {
+	if len(h.Lines) == 0 {
+		return h.Header + 1
+	}
+	return h.Lines[len(h.Lines)-1].Index + 1
+}
+
+// File is the diff of a single file.
+type File struct {
+	// Start is the index of the first line of the file in the diff, e.g. `diff --git a/x b/x`.
+	Start int
+	// End is the index after the last line of the file in the diff.
+	End int
+	// OldPath and NewPath are the paths without prefix, or /dev/null for added and deleted files.
+	OldPath, NewPath string
+	// OldMode and NewMode are set, if the file was added, deleted or its mode changed.
+	OldMode, NewMode string
+	// OldHash and NewHash are the abbreviated object names of the `index` line, e.g. `0000000` for added files.
+	OldHash, NewHash string
+	IsNew, IsDeleted bool
+	IsRename, IsCopy bool
+	// Similarity is the similarity index in percent of renamed and copied files.
+	Similarity int
+	IsBinary   bool
+	Hunks      []Hunk
+}
+
+// Path returns the path of the file, which is the old path for deleted files.
+func (f File) Path() string {
+	if f.IsDeleted || f.NewPath == devNull {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// Diff is a parsed unified diff of any number of files.
+type Diff struct {
+	Files []File
+	// Lines are all lines of the diff.
+	Lines []Line
+}
+
+const devNull = "/dev/null"
+
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// Parse parses the unified diff output of git, e.g. of `git diff` or `git show`.
+// Lines, which can't be parsed, are kept as lines of kind Other.
+// Lines may be broken by \n or \r\n.
+func Parse(diff string) Diff {
+	var (
+		result                     Diff
+		file                       *File
+		hunk                       *Hunk
+		oldRemaining, newRemaining int
+		oldNumber, newNumber       int
+		isBinaryPatch              bool
+	)
+
+	rawLines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	if len(diff) == 0 {
+		rawLines = nil
+	}
+	result.Lines = make([]Line, 0, len(rawLines))
+
+	for i, raw := range rawLines {
+		// Lines of files with Windows line endings end with a carriage return.
+		raw = strings.TrimSuffix(raw, "\r")
+		line := Line{Kind: Other, Index: i, Text: raw}
+
+		switch {
+		case hunk != nil && (oldRemaining > 0 || newRemaining > 0) && isContent(raw):
+			if len(raw) > 0 {
+				line.Text = raw[1:]
+			}
+			switch {
+			case strings.HasPrefix(raw, "-"):
+				line.Kind, line.OldNumber = Deleted, oldNumber
+				oldNumber++
+				oldRemaining--
+			case strings.HasPrefix(raw, "+"):
+				line.Kind, line.NewNumber = Added, newNumber
+				newNumber++
+				newRemaining--
+			default:
+				line.Kind, line.OldNumber, line.NewNumber = Context, oldNumber, newNumber
+				oldNumber++
+				newNumber++
+				oldRemaining--
+				newRemaining--
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		case hunk != nil && strings.HasPrefix(raw, `\`):
+			line.Kind, line.Text = NoNewline, raw[1:]
+			hunk.Lines = append(hunk.Lines, line)
+		case strings.HasPrefix(raw, "diff "):
+			result.Files = append(result.Files, File{Start: i})
+			file, hunk, isBinaryPatch = &result.Files[len(result.Files)-1], nil, false
+			file.OldPath, file.NewPath = gitHeaderPaths(raw)
+			line.Kind = FileHeader
+		case file == nil:
+			// Lines before the first file, e.g. the commit of `git show`.
+		case hunkHeaderRegex.MatchString(raw):
+			match := hunkHeaderRegex.FindStringSubmatch(raw)
+			file.Hunks = append(file.Hunks, Hunk{Header: i, Section: match[5]})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+			hunk.OldStart, hunk.OldCount = hunkRange(match[1], match[2])
+			hunk.NewStart, hunk.NewCount = hunkRange(match[3], match[4])
+			oldNumber, oldRemaining = hunk.OldStart, hunk.OldCount
+			newNumber, newRemaining = hunk.NewStart, hunk.NewCount
+			line.Kind = HunkHeader
+		case hunk != nil:
+			// Lines after the last hunk of a file, e.g. the log of a submodule.
+		case isBinaryPatch:
+			line.Kind = Binary
+		default:
+			line.Kind = parseFileHeader(file, raw)
+			isBinaryPatch = raw == "GIT binary patch"
+		}
+
+		if file != nil && line.Kind != Other {
+			file.End = i + 1
+		}
+		result.Lines = append(result.Lines, line)
+	}
+	return result
+}
+
+// parseFileHeader parses a line of the header of the file and returns its kind.
+func parseFileHeader(file *File, line string) LineKind {
+	switch {
+	case strings.HasPrefix(line, "--- "):
+		file.OldPath = headerPath(line[4:], "a/")
+	case strings.HasPrefix(line, "+++ "):
+		file.NewPath = headerPath(line[4:], "b/")
+	case strings.HasPrefix(line, "old mode "):
+		file.OldMode = line[9:]
+	case strings.HasPrefix(line, "new mode "):
+		file.NewMode = line[9:]
+	case strings.HasPrefix(line, "new file mode "):
+		file.IsNew, file.NewMode, file.OldPath = true, line[14:], devNull
+	case strings.HasPrefix(line, "deleted file mode "):
+		file.IsDeleted, file.OldMode, file.NewPath = true, line[18:], devNull
+	case strings.HasPrefix(line, "rename from "):
+		file.IsRename, file.OldPath = true, unquote(line[12:])
+	case strings.HasPrefix(line, "rename to "):
+		file.IsRename, file.NewPath = true, unquote(line[10:])
+	case strings.HasPrefix(line, "copy from "):
+		file.IsCopy, file.OldPath = true, unquote(line[10:])
+	case strings.HasPrefix(line, "copy to "):
+		file.IsCopy, file.NewPath = true, unquote(line[8:])
+	case strings.HasPrefix(line, "similarity index "):
+		file.Similarity, _ = strconv.Atoi(strings.TrimSuffix(line[17:], "%"))
+	case strings.HasPrefix(line, "index "):
+		hashes, _, _ := strings.Cut(line[6:], " ")
+		file.OldHash, file.NewHash, _ = strings.Cut(hashes, "..")
+	case strings.HasPrefix(line, "dissimilarity index "):
+	case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+		file.IsBinary = true
+		return Binary
+	default:
+		return Other
+	}
+	return FileHeader
+}
+
+// gitHeaderPaths returns the paths of a `diff --git a/x b/y` line. As the paths may contain spaces,
+// they can only be split if they are equal. Otherwise they are taken from the following header lines.
+func gitHeaderPaths(line string) (string, string) {
+	paths, ok := strings.CutPrefix(line, "diff --git ")
+	if !ok {
+		return "", ""
+	}
+	if strings.HasPrefix(paths, `"`) {
+		if oldPath, rest, ok := cutQuoted(paths); ok {
+			return headerPath(oldPath, "a/"), headerPath(strings.TrimPrefix(rest, " "), "b/")
+		}
+	}
+	if half := (len(paths) - 1) / 2; len(paths)%2 == 1 && paths[half] == ' ' {
+		oldPath, newPath := headerPath(paths[:half], "a/"), headerPath(paths[half+1:], "b/")
+		if oldPath == newPath {
+			return oldPath, newPath
+		}
+	}
+	return "", ""
+}
+
+// headerPath returns the path of a header line without its prefix, e.g. `a/`.
+// Git adds a tab after paths with spaces and quotes paths with special characters.
+func headerPath(path, prefix string) string {
+	path = unquote(strings.TrimSuffix(path, "\t"))
+	if path == devNull {
+		return path
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// unquote unquotes a path, which git quoted with C-style escapes.
+func unquote(path string) string {
+	if unquoted, rest, ok := cutQuoted(path); ok && len(rest) == 0 {
+		return unquoted
+	}
+	return path
+}
+
+// cutQuoted cuts a quoted string from the start of the text and returns it unquoted with the rest.
+func cutQuoted(text string) (string, string, bool) {
+	if !strings.HasPrefix(text, `"`) {
+		return "", text, false
+	}
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			unquoted, err := strconv.Unquote(text[:i+1])
+			if err != nil {
+				return "", text, false
+			}
+			return unquoted, text[i+1:], true
+		}
+	}
+	return "", text, false
+}
+
+func isContent(line string) bool {
+	return len(line) == 0 || line[0] == ' ' || line[0] == '+' || line[0] == '-'
+}
+
+// hunkRange returns the start and count of a range of a hunk header. The count is 1, if omitted.
+func hunkRange(start, count string) (int, int) {
+	startNumber, _ := strconv.Atoi(start)
+	if len(count) == 0 {
+		return startNumber, 1
+	}
+	countNumber, _ := strconv.Atoi(count)
+	return startNumber, countNumber
+}
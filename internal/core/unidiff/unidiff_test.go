@@ -0,0 +1,216 @@
+package unidiff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The corpus in testdata is the output of git for a repository with all kinds of changes.
+
+func readCorpus(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// fileSummary is the metadata of a file without its hunks.
+type fileSummary struct {
+	oldPath, newPath string
+	oldMode, newMode string
+	flags            string
+	similarity       int
+	hunks            int
+}
+
+func summarize(file File) fileSummary {
+	var flags []string
+	for _, flag := range []struct {
+		name  string
+		isSet bool
+	}{{"new", file.IsNew}, {"deleted", file.IsDeleted}, {"rename", file.IsRename}, {"copy", file.IsCopy}, {"binary", file.IsBinary}} {
+		if flag.isSet {
+			flags = append(flags, flag.name)
+		}
+	}
+	return fileSummary{
+		oldPath:    file.OldPath,
+		newPath:    file.NewPath,
+		oldMode:    file.OldMode,
+		newMode:    file.NewMode,
+		flags:      strings.Join(flags, ","),
+		similarity: file.Similarity,
+		hunks:      len(file.Hunks),
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect []fileSummary
+	}{
+		{
+			name: "all.diff",
+			expect: []fileSummary{
+				{oldPath: "/dev/null", newPath: "added.txt", newMode: "100644", flags: "new", hunks: 1},
+				{oldPath: "/dev/null", newPath: "copied.txt", newMode: "100644", flags: "new", hunks: 1},
+				{oldPath: "data.bin", newPath: "data.bin", flags: "binary"},
+				{oldPath: "main.go", newPath: "main.go", hunks: 2},
+				{oldPath: "mode.sh", newPath: "mode.sh", oldMode: "100644", newMode: "100755"},
+				{oldPath: "nonl.txt", newPath: "nonl.txt", hunks: 1},
+				{oldPath: "remove.txt", newPath: "/dev/null", oldMode: "100644", flags: "deleted", hunks: 1},
+				{oldPath: "rename_me.txt", newPath: "renamed.txt", flags: "rename", similarity: 94, hunks: 1},
+				{oldPath: "with space.txt", newPath: "with space.txt", hunks: 1},
+				{oldPath: "ümlaut.txt", newPath: "ümlaut.txt", hunks: 1},
+			},
+		},
+		{
+			name:   "copy.diff",
+			expect: []fileSummary{{oldPath: "long.txt", newPath: "copied.txt", flags: "copy", similarity: 94, hunks: 1}},
+		},
+		{
+			name: "norenames.diff",
+			expect: []fileSummary{
+				{oldPath: "rename_me.txt", newPath: "/dev/null", oldMode: "100644", flags: "deleted", hunks: 1},
+				{oldPath: "/dev/null", newPath: "renamed.txt", newMode: "100644", flags: "new", hunks: 1},
+			},
+		},
+		{
+			name:   "binary_patch.diff",
+			expect: []fileSummary{{oldPath: "data.bin", newPath: "data.bin", flags: "binary"}},
+		},
+		{
+			name:   "show.diff",
+			expect: []fileSummary{{oldPath: "main.go", newPath: "main.go", hunks: 2}},
+		},
+	}
+
+	for _, test := range tests {
+		raw := readCorpus(t, test.name)
+		diff := Parse(raw)
+
+		var got []fileSummary
+		for _, file := range diff.Files {
+			got = append(got, summarize(file))
+		}
+		if !reflect.DeepEqual(got, test.expect) {
+			t.Errorf("Parse(%s) files\n got %+v\n expected %+v", test.name, got, test.expect)
+		}
+		if lines := strings.Split(strings.TrimSuffix(raw, "\n"), "\n"); len(diff.Lines) != len(lines) {
+			t.Errorf("Parse(%s) got %d lines, expected %d", test.name, len(diff.Lines), len(lines))
+		}
+	}
+}
+
+func TestParseHunks(t *testing.T) {
+	diff := Parse(readCorpus(t, "all.diff"))
+	file := diff.Files[3]
+
+	expect := []Hunk{
+		{Header: 62, OldStart: 3, OldCount: 7, NewStart: 3, NewCount: 7, Section: "package main"},
+		{Header: 71, OldStart: 15, OldCount: 6, NewStart: 15, NewCount: 7, Section: "func unused() {"},
+	}
+	for i, hunk := range file.Hunks {
+		hunk.Lines = nil
+		if !reflect.DeepEqual(hunk, expect[i]) {
+			t.Errorf("hunk %d got %+v, expected %+v", i, hunk, expect[i])
+		}
+	}
+	if file.Start != 58 || file.End != 79 {
+		t.Errorf("file got lines %d to %d, expected 58 to 79", file.Start, file.End)
+	}
+	if file.OldHash != "62fe303" || file.NewHash != "dc15d02" {
+		t.Errorf("file got hashes %s..%s, expected 62fe303..dc15d02", file.OldHash, file.NewHash)
+	}
+	if end := file.Hunks[0].End(); end != 71 {
+		t.Errorf("first hunk ends at %d, expected 71", end)
+	}
+
+	expectLines := []Line{
+		{Kind: Context, Index: 63, Text: `import "fmt"`, OldNumber: 3, NewNumber: 3},
+		{Kind: Context, Index: 64, Text: "", OldNumber: 4, NewNumber: 4},
+		{Kind: Context, Index: 65, Text: "func greet(name string) string {", OldNumber: 5, NewNumber: 5},
+		{Kind: Deleted, Index: 66, Text: `	return "Hello, " + name`, OldNumber: 6},
+		{Kind: Added, Index: 67, Text: `	return fmt.Sprintf("Hello, %s!", name)`, NewNumber: 6},
+		{Kind: Context, Index: 68, Text: "}", OldNumber: 7, NewNumber: 7},
+	}
+	if got := file.Hunks[0].Lines[:len(expectLines)]; !reflect.DeepEqual(got, expectLines) {
+		t.Errorf("lines got %+v\n expected %+v", got, expectLines)
+	}
+}
+
+func TestParseLineKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		start  int
+		expect []LineKind
+	}{
+		{
+			// The file without newline at its end.
+			name:   "all.diff",
+			start:  82,
+			expect: []LineKind{FileHeader, FileHeader, FileHeader, FileHeader, HunkHeader, Deleted, NoNewline, Added, NoNewline, FileHeader},
+		},
+		{
+			name:   "binary_patch.diff",
+			expect: []LineKind{FileHeader, FileHeader, Binary, Binary, Binary, Binary, Binary, Binary, Binary},
+		},
+		{
+			// The `---` line of the commit isn't a file header.
+			name:   "show.diff",
+			start:  6,
+			expect: []LineKind{Other, Other, Other, Other, Other, FileHeader, FileHeader, FileHeader, FileHeader, HunkHeader, Context},
+		},
+	}
+
+	for _, test := range tests {
+		diff := Parse(readCorpus(t, test.name))
+		var got []LineKind
+		for _, line := range diff.Lines[test.start : test.start+len(test.expect)] {
+			got = append(got, line.Kind)
+		}
+		if !reflect.DeepEqual(got, test.expect) {
+			t.Errorf("Parse(%s) kinds from line %d got %v, expected %v", test.name, test.start, got, test.expect)
+		}
+	}
+}
+
+func TestParseCRLF(t *testing.T) {
+	diff := Parse(readCorpus(t, "crlf.diff"))
+
+	expect := []Line{
+		{Kind: HunkHeader, Index: 4, Text: "@@ -1,3 +1,4 @@"},
+		{Kind: Context, Index: 5, Text: "one", OldNumber: 1, NewNumber: 1},
+		{Kind: Deleted, Index: 6, Text: "two", OldNumber: 2},
+		{Kind: Added, Index: 7, Text: "2", NewNumber: 2},
+		{Kind: Context, Index: 8, Text: "three", OldNumber: 3, NewNumber: 3},
+		{Kind: Added, Index: 9, Text: "four", NewNumber: 4},
+		{Kind: NoNewline, Index: 10, Text: " No newline at end of file"},
+	}
+	if got := diff.Lines[4:]; !reflect.DeepEqual(got, expect) {
+		t.Errorf("lines got %+v\n expected %+v", got, expect)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].Path() != "win.txt" {
+		t.Errorf("Expected the file win.txt, got %+v", diff.Files)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	if diff := Parse(""); len(diff.Files) != 0 || len(diff.Lines) != 0 {
+		t.Errorf("Parse of empty diff got %+v", diff)
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	diff := Parse(readCorpus(t, "all.diff"))
+	for i, expect := range map[int]string{0: "added.txt", 6: "remove.txt", 7: "renamed.txt"} {
+		if path := diff.Files[i].Path(); path != expect {
+			t.Errorf("file %d got path %q, expected %q", i, path, expect)
+		}
+	}
+}
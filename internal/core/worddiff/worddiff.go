@@ -0,0 +1,133 @@
+// Package worddiff compares two versions of a line word by word,
+// e.g. to emphasize the changed words of a modified line in a diff.
+package worddiff
+
+import (
+	"unicode"
+)
+
+const (
+	// minSimilarity is the minimum share of unchanged characters of both lines.
+	// Changes of less similar lines aren't emphasized, as almost everything changed.
+	minSimilarity = 0.4
+	// maxComparisons limits the size of the table of the longest common subsequence.
+	maxComparisons = 1 << 16
+)
+
+// Diff compares the words of the old and the new line. It returns for each rune
+// of both lines whether it belongs to a changed word. It reports false, if the
+// lines are too different or too long to compare them.
+func Diff(oldLine, newLine string) ([]bool, []bool, bool) {
+	var (
+		oldWords = Split(oldLine)
+		newWords = Split(newLine)
+	)
+	if len(oldWords)*len(newWords) > maxComparisons {
+		return nil, nil, false
+	}
+
+	oldIsCommon, newIsCommon := commonWords(oldWords, newWords)
+
+	var (
+		oldChanged, oldUnchanged, oldTotal = changedRunes(oldWords, oldIsCommon)
+		newChanged, newUnchanged, newTotal = changedRunes(newWords, newIsCommon)
+	)
+	if total := oldTotal + newTotal; total > 0 {
+		if float64(oldUnchanged+newUnchanged)/float64(total) < minSimilarity {
+			return nil, nil, false
+		}
+	}
+	return oldChanged, newChanged, true
+}
+
+// Split splits the line into words. Letters, digits and underscores form words,
+// as well as runs of whitespace. Every other rune is a word on its own.
+func Split(line string) []string {
+	var (
+		words []string
+		runes = []rune(line)
+		start int
+	)
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && isSameWord(runes[i-1], runes[i]) {
+			continue
+		}
+		words = append(words, string(runes[start:i]))
+		start = i
+	}
+	return words
+}
+
+func isSameWord(previous, r rune) bool {
+	switch {
+	case isWordRune(previous):
+		return isWordRune(r)
+	case unicode.IsSpace(previous):
+		return unicode.IsSpace(r)
+	default:
+		return false
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// commonWords marks the words of the longest common subsequence of both lines.
+func commonWords(oldWords, newWords []string) ([]bool, []bool) {
+	// lengths[i][j] is the length of the longest common subsequence
+	// of oldWords[i:] and newWords[j:].
+	lengths := make([][]int, len(oldWords)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newWords)+1)
+	}
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var (
+		oldIsCommon = make([]bool, len(oldWords))
+		newIsCommon = make([]bool, len(newWords))
+	)
+	for i, j := 0, 0; i < len(oldWords) && j < len(newWords); {
+		switch {
+		case oldWords[i] == newWords[j]:
+			oldIsCommon[i], newIsCommon[j] = true, true
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return oldIsCommon, newIsCommon
+}
+
+// changedRunes marks the runes of the words, which are not common. It also returns
+// the number of unchanged and of all runes, ignoring whitespace.
+func changedRunes(words []string, isCommon []bool) ([]bool, int, int) {
+	var (
+		changed          []bool
+		unchanged, total int
+	)
+	for i, word := range words {
+		for _, r := range word {
+			changed = append(changed, !isCommon[i])
+			if unicode.IsSpace(r) {
+				continue
+			}
+			total++
+			if isCommon[i] {
+				unchanged++
+			}
+		}
+	}
+	return changed, unchanged, total
+}
@@ -0,0 +1,81 @@
+package worddiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	words := Split("	if err != nil {  return err_1 }")
+	expect := []string{"\t", "if", " ", "err", " ", "!", "=", " ", "nil", " ", "{", "  ", "return", " ", "err_1", " ", "}"}
+	if !reflect.DeepEqual(words, expect) {
+		t.Errorf("Split got %q", words)
+	}
+	if words := Split(""); len(words) != 0 {
+		t.Errorf("Split of empty line got %q", words)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		oldLine, newLine string
+		expectOld        string
+		expectNew        string
+	}{
+		{
+			oldLine:   "func recieve(x int) error {",
+			newLine:   "func receive(x int) error {",
+			expectOld: "     ^^^^^^^               ",
+			expectNew: "     ^^^^^^^               ",
+		},
+		{
+			oldLine:   "a := b",
+			newLine:   "a := b + c",
+			expectOld: "      ",
+			expectNew: "      ^^^^",
+		},
+		{
+			oldLine:   "x := \"ä\"",
+			newLine:   "x := \"ö\"",
+			expectOld: "      ^ ",
+			expectNew: "      ^ ",
+		},
+		{
+			oldLine:   "same",
+			newLine:   "same",
+			expectOld: "    ",
+			expectNew: "    ",
+		},
+	}
+
+	for _, test := range tests {
+		oldChanged, newChanged, ok := Diff(test.oldLine, test.newLine)
+		if !ok {
+			t.Errorf("Diff(%q, %q) is not ok", test.oldLine, test.newLine)
+			continue
+		}
+		if got := marks(oldChanged); got != test.expectOld {
+			t.Errorf("Diff(%q, %q) old [%s], expected [%s]", test.oldLine, test.newLine, got, test.expectOld)
+		}
+		if got := marks(newChanged); got != test.expectNew {
+			t.Errorf("Diff(%q, %q) new [%s], expected [%s]", test.oldLine, test.newLine, got, test.expectNew)
+		}
+	}
+}
+
+func TestDiffOfDifferentLines(t *testing.T) {
+	if _, _, ok := Diff("return nil", "fmt.Println(x, y)"); ok {
+		t.Error("Diff of different lines is ok")
+	}
+}
+
+func marks(changed []bool) string {
+	runes := make([]rune, len(changed))
+	for i, isChanged := range changed {
+		runes[i] = ' '
+		if isChanged {
+			runes[i] = '^'
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,59 @@
+// Package blame provides ui to show which commit last changed each line of a file.
+package blame
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+)
+
+// ShowDialog shows the blame of the file at path in the work tree.
+// Diffs of commits larger than the limit are collapsed.
+func ShowDialog(path string, limit diff.Limit, onClose tea.Cmd) tea.Cmd {
+	content := NewDialogContent(git.BlameOptions{Path: path}, limit)
+	return dialog.Show(content, onClose, dialog.FullScreenDisplayMode)
+}
+
+type loadedMsg struct {
+	opts  git.BlameOptions
+	lines []git.BlameLine
+	err   error
+}
+
+func load(opts git.BlameOptions) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := git.Blame(opts)
+		return loadedMsg{opts: opts, lines: lines, err: err}
+	}
+}
+
+// blameParentMsg requests to blame the file in the parent of the line's commit.
+type blameParentMsg struct {
+	line git.BlameLine
+}
+
+func blameParent(line git.BlameLine) tea.Cmd {
+	return func() tea.Msg {
+		return blameParentMsg{line: line}
+	}
+}
+
+func showCommit(commit git.BlameCommit, limit diff.Limit) tea.Cmd {
+	if !commit.IsCommitted() {
+		return showError(errors.New("The line is not committed yet."))
+	}
+	title := fmt.Sprintf("Commit %s", commit.ShortHash())
+	return diff.ShowDialog(title, limit, func() (string, error) {
+		return git.ShowCommit(commit.Hash)
+	}, nil)
+}
+
+func showError(err error) tea.Cmd {
+	content := info.NewDialogContent(info.New("Blame error", err.Error()))
+	return dialog.Show(content, nil, dialog.CenterDisplayMode)
+}
@@ -0,0 +1,174 @@
+package blame
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/container"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+	"github.com/michaelhass/gitglance/internal/domain/tag"
+)
+
+// DialogContent shows the blame of a file. Blaming the parent of a line's commit
+// steps back through the history, e.g. past a commit that only reformatted the file.
+type DialogContent struct {
+	container container.Model
+	// history of blamed revisions. The last one is shown.
+	history []revision
+	keys    KeyMap
+}
+
+// revision is a blamed revision with the index of its focused line,
+// which is focused again after going back to it.
+type revision struct {
+	opts         git.BlameOptions
+	focusedIndex int
+}
+
+func NewDialogContent(opts git.BlameOptions, limit diff.Limit) DialogContent {
+	keys := newKeyMap()
+	itemHandler := func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.SelectItemMsg:
+			if item, ok := msg.Item.(lineItem); ok {
+				return showCommit(item.line.Commit, limit)
+			}
+		case list.CustomItemMsg:
+			item, ok := msg.Item.(lineItem)
+			if !ok {
+				return nil
+			}
+			switch {
+			case key.Matches(msg.KeyMsg, keys.blameParent):
+				return blameParent(item.line)
+			case key.Matches(msg.KeyMsg, keys.tag):
+				return tag.ShowCreateDialog(item.line.Commit.Hash, nil)
+			}
+		}
+		return nil
+	}
+
+	content, _ := list.NewContainer(list.New(title(opts), itemHandler, keys.KeyMap)).UpdateFocus(true)
+	return DialogContent{
+		container: content,
+		history:   []revision{{opts: opts}},
+		keys:      keys,
+	}
+}
+
+func (dc DialogContent) Init() tea.Cmd {
+	return load(dc.current())
+}
+
+func (dc DialogContent) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
+	switch msg := msg.(type) {
+	case loadedMsg:
+		if msg.err != nil {
+			// Stay at the previous revision, if the parent can't be blamed.
+			if len(dc.history) > 1 && dc.current() == msg.opts {
+				dc.history = dc.history[:len(dc.history)-1]
+			}
+			return dc, showError(msg.err)
+		}
+		if msg.opts != dc.current() {
+			return dc, nil
+		}
+		return dc.setLines(msg.opts, msg.lines)
+	case blameParentMsg:
+		if !msg.line.Commit.IsCommitted() {
+			return dc, showError(errors.New("The line is not committed yet."))
+		}
+		opts, ok := msg.line.ParentBlameOptions()
+		if !ok {
+			return dc, showError(fmt.Errorf("Commit %s has no parent.", msg.line.Commit.ShortHash()))
+		}
+		// The parent is blamed at the line's position in its commit, which is close to
+		// its position in the parent. The list clamps it to the lines of the parent.
+		dc.history[len(dc.history)-1].focusedIndex = dc.focusedIndex()
+		dc.history = append(dc.history, revision{opts: opts, focusedIndex: msg.line.OriginalLineNumber - 1})
+		return dc, load(opts)
+	case tea.KeyMsg:
+		if key.Matches(msg, dc.keys.back) && len(dc.history) > 1 && !dc.IsCapturingInput() {
+			dc.history = dc.history[:len(dc.history)-1]
+			return dc, load(dc.current())
+		}
+	}
+
+	var cmd tea.Cmd
+	dc.container, cmd = dc.container.Update(msg)
+	return dc, cmd
+}
+
+func (dc DialogContent) View() string {
+	return dc.container.View()
+}
+
+func (dc DialogContent) SetSize(width, height int) dialog.Content {
+	dc.container = dc.container.SetSize(width, height)
+	return dc
+}
+
+func (dc DialogContent) Help() []key.Binding {
+	if dc.IsCapturingInput() {
+		return dc.container.Content().KeyMap().ShortHelp()
+	}
+	keys := dc.keys
+	keys.back.SetEnabled(len(dc.history) > 1)
+	return keys.ShortHelp()
+}
+
+func (dc DialogContent) IsCapturingInput() bool {
+	return dc.container.IsCapturingInput()
+}
+
+func (dc DialogContent) current() git.BlameOptions {
+	return dc.history[len(dc.history)-1].opts
+}
+
+func (dc DialogContent) focusedIndex() int {
+	content, ok := dc.container.Content().(list.ContainerContent)
+	if !ok {
+		return 0
+	}
+	return content.FocusedIndex()
+}
+
+func (dc DialogContent) setLines(opts git.BlameOptions, lines []git.BlameLine) (DialogContent, tea.Cmd) {
+	content, ok := dc.container.Content().(list.ContainerContent)
+	if !ok {
+		return dc, nil
+	}
+
+	var (
+		now             = time.Now()
+		lineNumberWidth = len(fmt.Sprint(len(lines)))
+		items           = make([]list.Item, len(lines))
+	)
+	for i, line := range lines {
+		items[i] = lineItem{line: line, now: now, lineNumberWidth: lineNumberWidth}
+	}
+
+	var cmds [2]tea.Cmd
+	content.Model, cmds[0] = content.SetItems(items)
+	content.Model, cmds[1] = content.SetFocusedIndex(dc.history[len(dc.history)-1].focusedIndex)
+	content.Model = content.SetTitle(title(opts))
+	dc.container = dc.container.SetContent(content)
+	return dc, tea.Batch(cmds[:]...)
+}
+
+func title(opts git.BlameOptions) string {
+	if len(opts.Revision) == 0 {
+		return fmt.Sprintf("Blame %s", opts.Path)
+	}
+	shortRevision := opts.Revision
+	if len(shortRevision) > 8 {
+		shortRevision = shortRevision[:8]
+	}
+	return fmt.Sprintf("Blame %s @ %s", opts.Path, shortRevision)
+}
@@ -0,0 +1,98 @@
+package blame
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/textwrap"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+)
+
+const (
+	authorWidth = 14
+	tabWidth    = 4
+)
+
+// ageColors are used for lines changed within the corresponding maxAges.
+// Older lines use the subtle text color.
+var (
+	ageColors = []lipgloss.Color{"208", "214", "220", "150", "109"}
+	maxAges   = []time.Duration{
+		time.Hour * 24,
+		time.Hour * 24 * 7,
+		time.Hour * 24 * 30,
+		time.Hour * 24 * 180,
+		time.Hour * 24 * 365,
+	}
+	notCommittedStyle = style.AddedText
+	oldStyle          = style.SublteText
+)
+
+type lineItem struct {
+	line git.BlameLine
+	// now is the time to compute the age of the line relative to.
+	now             time.Time
+	lineNumberWidth int
+}
+
+func (item lineItem) Render() string {
+	return item.RenderWithStyle(lipgloss.NewStyle())
+}
+
+// RenderWithStyle renders the commit information colored by age
+// and the content of the line with the style of the list.
+func (item lineItem) RenderWithStyle(style lipgloss.Style) string {
+	commit := item.line.Commit
+
+	var info string
+	if commit.IsCommitted() {
+		info = fmt.Sprintf(
+			"%s %-*s %4s",
+			commit.ShortHash(),
+			authorWidth,
+			textwrap.Truncate(commit.Author, authorWidth),
+			age(item.now.Sub(commit.AuthorTime)),
+		)
+	} else {
+		info = fmt.Sprintf("%-8s %-*s %4s", "", authorWidth, "Not committed", "")
+	}
+
+	content := strings.ReplaceAll(item.line.Content, "\t", strings.Repeat(" ", tabWidth))
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		item.ageStyle().Render(info),
+		style.Render(fmt.Sprintf(" %*d │ %s", item.lineNumberWidth, item.line.LineNumber, content)),
+	)
+}
+
+func (item lineItem) ageStyle() lipgloss.Style {
+	if !item.line.Commit.IsCommitted() {
+		return notCommittedStyle
+	}
+	lineAge := item.now.Sub(item.line.Commit.AuthorTime)
+	for i, maxAge := range maxAges {
+		if lineAge < maxAge {
+			return lipgloss.NewStyle().Foreground(ageColors[i])
+		}
+	}
+	return oldStyle
+}
+
+// age returns a short description of the duration, e.g. `3d` or `2y`.
+func age(d time.Duration) string {
+	const day = time.Hour * 24
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < day*30:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < day*365:
+		return fmt.Sprintf("%dmo", int(d/(day*30)))
+	}
+	return fmt.Sprintf("%dy", int(d/(day*365)))
+}
@@ -0,0 +1,36 @@
+package blame
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+)
+
+// KeyMap contains the list keys and the keys that don't need a focused line.
+type KeyMap struct {
+	list.KeyMap
+	back        key.Binding
+	blameParent key.Binding
+	tag         key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return append(km.KeyMap.ShortHelp(), km.back)
+}
+
+func newKeyMap() KeyMap {
+	keyMap := list.NewKeyMap("", "show commit", "")
+	keyMap.All.SetEnabled(false)
+	keyMap.Edit.SetEnabled(false)
+	keyMap.Delete.SetEnabled(false)
+	var (
+		blameParent = key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "blame parent"))
+		tag         = key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tag commit"))
+	)
+	keyMap.CustomKeys = []key.Binding{blameParent, tag}
+	return KeyMap{
+		KeyMap:      keyMap,
+		back:        key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "back")),
+		blameParent: blameParent,
+		tag:         tag,
+	}
+}
@@ -71,6 +71,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m, cmd = m.setMsg(msg.msg)
 		cmds = append(cmds, cmd)
 	case tea.KeyMsg:
+		if m.IsCapturingInput() {
+			break
+		}
 		switch {
 		case key.Matches(msg, m.keys.toggleFocus):
 			m, cmd = m.toggleFocus()
@@ -104,6 +107,9 @@ func (m Model) SetSize(width, height int) Model {
 }
 
 func (m Model) Help() []key.Binding {
+	if m.IsCapturingInput() {
+		return m.stagedFileList.Content().KeyMap().ShortHelp()
+	}
 	return []key.Binding{
 		m.keys.up,
 		m.keys.down,
@@ -112,6 +118,11 @@ func (m Model) Help() []key.Binding {
 	}
 }
 
+// IsCapturingInput reports whether the filter of the staged files is being typed.
+func (m Model) IsCapturingInput() bool {
+	return m.stagedFileList.IsFocused() && m.stagedFileList.IsCapturingInput()
+}
+
 func (m Model) setMsg(msg string) (Model, tea.Cmd) {
 	if input, ok := m.message.Content().(textinput.ContainerContent); ok {
 		input.Model = input.Model.
@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+// Limit is the size of diffs, which are collapsed until they are loaded anyway.
+// Limits, which aren't positive, are ignored.
+type Limit struct {
+	Bytes int
+	Lines int
+}
+
+// DefaultLimit collapses diffs larger than 1 MiB or 20000 lines.
+var DefaultLimit = Limit{Bytes: 1 << 20, Lines: 20000}
+
+// exceeds reports whether the diff is larger than the limit.
+func (l Limit) exceeds(rawDiff string) bool {
+	if l.Bytes > 0 && len(rawDiff) > l.Bytes {
+		return true
+	}
+	return l.Lines > 0 && strings.Count(rawDiff, "\n") > l.Lines
+}
+
+func (l Limit) String() string {
+	var parts []string
+	if l.Bytes > 0 {
+		parts = append(parts, formatSize(int64(l.Bytes)))
+	}
+	if l.Lines > 0 {
+		parts = append(parts, fmt.Sprintf("%d lines", l.Lines))
+	}
+	return strings.Join(parts, " or ")
+}
+
+func describeLargeDiff(rawDiff string, limit Limit) string {
+	return fmt.Sprintf(
+		"The diff of %s and %d lines is larger than %s.\nPress L to load it anyway.",
+		formatSize(int64(len(rawDiff))), strings.Count(rawDiff, "\n"), limit,
+	)
+}
+
+func describeGeneratedDiff(diff unidiff.Diff) string {
+	var paths []string
+	for _, file := range diff.Files {
+		paths = append(paths, file.Path())
+	}
+	return fmt.Sprintf(
+		"The diff of %d lines of generated files is collapsed: %s\nPress L to load it anyway.",
+		len(diff.Lines), strings.Join(paths, ", "),
+	)
+}
+
+// generatedRanges returns the hunks of each generated file.
+func (i Inspection) generatedRanges(diff unidiff.Diff) []lineRange {
+	var ranges []lineRange
+	for _, file := range diff.Files {
+		if i.isGenerated(file) && len(file.Hunks) > 0 {
+			ranges = append(ranges, lineRange{start: file.Hunks[0].Header, end: file.End})
+		}
+	}
+	return ranges
+}
+
+// canLoadAnyway reports whether the diff or some of its files are collapsed.
+func (m Model) canLoadAnyway() bool {
+	return len(m.collapsed) > 0 || len(m.generated) > 0
+}
+
+// loadAnyway shows the collapsed diff and the generated files.
+func (m Model) loadAnyway() Model {
+	if !m.canLoadAnyway() {
+		return m
+	}
+	m.isLoadedAnyway = true
+	return m.parse().render()
+}
@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/container"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+)
+
+// Loader loads the raw diff to display.
+type Loader func() (string, error)
+
+type loadedMsg struct {
+	diff       string
+	inspection Inspection
+	err        error
+}
+
+// DialogContent shows a diff, e.g. of a commit, in a dialog.
+type DialogContent struct {
+	container container.Model
+	loader    Loader
+}
+
+func NewDialogContent(title string, limit Limit, loader Loader) DialogContent {
+	content := container.New(NewContent(New().SetTitle(title).WithLimit(limit)))
+	content, _ = content.UpdateFocus(true)
+	return DialogContent{container: content, loader: loader}
+}
+
+// ShowDialog loads and shows a diff in a full screen dialog. It is collapsed, if it is larger than the limit.
+func ShowDialog(title string, limit Limit, loader Loader, onClose tea.Cmd) tea.Cmd {
+	return dialog.Show(NewDialogContent(title, limit, loader), onClose, dialog.FullScreenDisplayMode)
+}
+
+func (dc DialogContent) Init() tea.Cmd {
+	loader := dc.loader
+	return func() tea.Msg {
+		diff, err := loader()
+		return loadedMsg{diff: diff, inspection: Inspect(diff), err: err}
+	}
+}
+
+func (dc DialogContent) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
+	if msg, ok := msg.(loadedMsg); ok {
+		if content, ok := dc.container.Content().(ContainerContent); ok {
+			content.Model = content.SetInspectedContent(msg.diff, msg.inspection, msg.err)
+			dc.container = dc.container.SetContent(content)
+		}
+		return dc, nil
+	}
+
+	var cmd tea.Cmd
+	dc.container, cmd = dc.container.Update(msg)
+	return dc, cmd
+}
+
+func (dc DialogContent) View() string {
+	return dc.container.View()
+}
+
+func (dc DialogContent) SetSize(width, height int) dialog.Content {
+	dc.container = dc.container.SetSize(width, height)
+	return dc
+}
+
+func (dc DialogContent) Help() []key.Binding {
+	return dc.container.Content().KeyMap().ShortHelp()
+}
+
+func (dc DialogContent) IsCapturingInput() bool {
+	return dc.container.IsCapturingInput()
+}
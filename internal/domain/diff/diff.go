@@ -5,16 +5,21 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
 	"github.com/michaelhass/gitglance/internal/core/textwrap"
 	"github.com/michaelhass/gitglance/internal/core/ui/style"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
 )
 
 var (
 	normalTextStyle  = style.Text
 	addedTextStyle   = style.AddedText
 	removedTextStyle = style.RemovedText
+	fileHeaderStyle  = style.Text.Bold(true)
+	hunkHeaderStyle  = style.HunkHeaderText
 )
 
 // The Model to display a git diff output.
@@ -22,27 +27,53 @@ type Model struct {
 	viewport    viewport.Model
 	textBuilder *textwrap.Builder
 	keys        KeyMap
+	title       string
 	err         error
 	width       int
 	isReady     bool
 	isFocused   bool
+	// isSplitView shows old and new lines side by side, if the diff is wide enough.
+	isSplitView bool
+	// parsed diff, highlights and structure of the diff they were computed for.
+	parsed          unidiff.Diff
+	highlights      *highlights
+	highlightedDiff string
+	search          search
+	// splitRows are the rows of the split view.
+	splitRows []splitRow
+	// layout positions the lines of the view, which are rendered once they are visible.
+	layout layout
+	// format the diff is loaded with, if it can be changed.
+	format    git.DiffFormat
+	structure structure
+	// foldedHunks are the headers of the folded hunks.
+	foldedHunks map[int]bool
+	// isContextFolded folds the long runs of context, except for the expanded ones.
+	isContextFolded bool
+	expandedContext map[int]bool
+	folds           folds
+	scrollTarget    *scrollTarget
+	inspection      Inspection
+	// limit is the size of diffs, which are collapsed until they are loaded anyway.
+	limit Limit
+	// collapsed describes why the diff is collapsed, e.g. as it is too large.
+	collapsed string
+	// generated are the hunks of generated files, which are folded.
+	generated      []lineRange
+	isLoadedAnyway bool
 }
 
 func New() Model {
-	lineRenderer := func(line string) textwrap.Renderer {
-		if strings.HasPrefix(line, "+") {
-			return addedTextStyle
-		} else if strings.HasPrefix(line, "-") {
-			return removedTextStyle
-		} else {
-			return normalTextStyle
-		}
-	}
-
 	textBuilder := textwrap.NewBuilder()
-	textBuilder.SetLineRenderer(lineRenderer)
+	textBuilder.SetIndexedLineRenderer(newLineRenderer(nil, nil, search{}))
 
-	return Model{textBuilder: textBuilder, keys: newDiffKeyMap()}
+	return Model{textBuilder: textBuilder, keys: newDiffKeyMap(), title: "Diff", limit: DefaultLimit}
+}
+
+// WithLimit collapses diffs larger than the limit until they are loaded anyway.
+func (m Model) WithLimit(limit Limit) Model {
+	m.limit = limit
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -54,6 +85,48 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		// Keys are added to the search while it is typed.
+		if m.search.isTyping && msg.Type != tea.KeyUp && msg.Type != tea.KeyDown {
+			return m.updateSearch(msg), nil
+		}
+
+		if model, cmd, ok := m.updateFormat(msg); ok {
+			return model, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.toggleSplit):
+			m.isSplitView = !m.isSplitView
+			m = m.render()
+			if match, ok := m.search.currentMatch(); ok {
+				m.viewport.SetYOffset(m.lineOffset(match.line) - m.viewport.Height/3)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.search):
+			m.search.isTyping = true
+			return m.setQuery(""), nil
+		case key.Matches(msg, m.keys.nextMatch):
+			return m.focusMatch(m.search.current + 1), nil
+		case key.Matches(msg, m.keys.prevMatch):
+			return m.focusMatch(m.search.current - 1), nil
+		case key.Matches(msg, m.keys.nextHunk):
+			return m.scrollToNext(m.hunkHeaders(), true), nil
+		case key.Matches(msg, m.keys.prevHunk):
+			return m.scrollToNext(m.hunkHeaders(), false), nil
+		case key.Matches(msg, m.keys.nextFile):
+			return m.scrollToNext(m.structure.files, true), nil
+		case key.Matches(msg, m.keys.prevFile):
+			return m.scrollToNext(m.structure.files, false), nil
+		case key.Matches(msg, m.keys.foldHunk):
+			return m.toggleHunkFold(), nil
+		case key.Matches(msg, m.keys.foldContext):
+			return m.toggleContextFold(), nil
+		case key.Matches(msg, m.keys.loadAnyway):
+			return m.loadAnyway(), nil
+		}
+	}
+
 	var cmd tea.Cmd
 	m.viewport, cmd = m.viewport.Update(msg)
 	return m, cmd
@@ -69,11 +142,35 @@ func (m Model) View() string {
 		return ""
 	}
 
-	return m.viewport.View()
+	if m.err != nil || len(m.collapsed) > 0 {
+		return m.viewport.View()
+	}
+
+	// The viewport only contains empty lines to keep track of the scroll position.
+	// The visible lines are rendered in a copy of it.
+	viewport := m.viewport
+	viewport.SetContent(strings.Join(m.visibleLines(), "\n"))
+	viewport.SetYOffset(0)
+	return viewport.View()
 }
 
 func (m Model) Title() string {
-	return "Diff"
+	title := m.title
+	if current, ok := m.currentHunk(); ok {
+		title = fmt.Sprintf("%s %d/%d", title, current+1, len(m.structure.hunks))
+	}
+	if format := describeFormat(m.format); len(format) > 0 {
+		title = fmt.Sprintf("%s (%s)", title, format)
+	}
+	if m.search.isTyping || len(m.search.query) > 0 {
+		title = fmt.Sprintf("%s %s", title, m.search.title())
+	}
+	return title
+}
+
+func (m Model) SetTitle(title string) Model {
+	m.title = title
+	return m
 }
 
 func (m Model) SetSize(width, height int) Model {
@@ -90,28 +187,102 @@ func (m Model) SetSize(width, height int) Model {
 	// TODO: Fix need for extra padding
 	extraPadding := 5
 	m.textBuilder.SetLineLength(width - extraPadding)
-	m = m.SetContent(m.textBuilder.RawString(), m.err)
+	m = m.SetContent(m.highlightedDiff, m.err)
 	return m
 }
 
 func (m Model) KeyMap() help.KeyMap {
-	return m.keys
+	if m.search.isTyping {
+		return newSearchKeyMap()
+	}
+
+	// The keys to navigate between matches are only described, if there are matches.
+	keys := m.keys
+	keys.nextMatch.SetEnabled(m.search.hasMatches())
+	keys.prevMatch.SetEnabled(m.search.hasMatches())
+	if m.isSplitView {
+		keys.toggleSplit.SetHelp("v", "unified view")
+	}
+	keys.loadAnyway.SetEnabled(m.canLoadAnyway())
+	return keys
 }
 
+// SetContent sets the diff. The details of its files are kept, as long as the diff doesn't change.
 func (m Model) SetContent(rawDiff string, err error) Model {
+	inspection := m.inspection
+	if rawDiff != m.highlightedDiff {
+		inspection = Inspection{}
+	}
+	return m.SetInspectedContent(rawDiff, inspection, err)
+}
+
+// SetInspectedContent sets the diff together with the details of its files, which were loaded by Inspect.
+func (m Model) SetInspectedContent(rawDiff string, inspection Inspection, err error) Model {
 	m.err = err
+	if m.highlights == nil || rawDiff != m.highlightedDiff {
+		m.highlightedDiff, m.inspection, m.isLoadedAnyway = rawDiff, inspection, false
+		m.foldedHunks, m.expandedContext, m.scrollTarget = nil, nil, nil
+		m = m.parse()
+	} else if !inspection.equal(m.inspection) {
+		m.inspection = inspection
+		m = m.parse()
+	}
+	return m.render()
+}
+
+// parse parses the diff and computes everything, which only changes
+// together with the diff, its inspection or when it is loaded anyway.
+func (m Model) parse() Model {
+	m.collapsed, m.parsed = "", unidiff.Diff{}
+	if !m.isLoadedAnyway && m.err == nil && m.limit.exceeds(m.highlightedDiff) {
+		m.collapsed = describeLargeDiff(m.highlightedDiff, m.limit)
+	} else {
+		m.parsed = unidiff.Parse(normalizedBreaks(m.highlightedDiff))
+		if !m.isLoadedAnyway && m.inspection.isAllGenerated(m.parsed) {
+			m.collapsed = describeGeneratedDiff(m.parsed)
+			m.parsed = unidiff.Diff{}
+		}
+	}
+
+	var rawDiff string
+	if len(m.collapsed) == 0 {
+		rawDiff = m.highlightedDiff
+		m.parsed, rawDiff = m.inspection.summarizeBinaryFiles(m.parsed, rawDiff)
+	}
 	m.textBuilder.WriteString(rawDiff)
+	m.highlights = newHighlights(m.parsed)
+	m.splitRows = splitRows(m.parsed)
+	m.search = m.search.find(rawDiff)
+	m.structure = newStructure(m.parsed)
+	m.generated = nil
+	if !m.isLoadedAnyway {
+		m.generated = m.inspection.generatedRanges(m.parsed)
+	}
+	return m
+}
+
+// render lays out the diff in the viewport, e.g. after the diff, the size or the folds changed.
+// The lines are only wrapped and styled in View, once they are visible.
+func (m Model) render() Model {
+	m.textBuilder.SetIndexedLineRenderer(newLineRenderer(m.parsed.Lines, m.highlights, m.search))
+	m.folds = newFolds(m.structure, m.generated, m.foldedHunks, m.isContextFolded, m.expandedContext)
 
 	if !m.isReady {
 		return m
 	}
 
+	m.layout = layout{}
 	if m.err != nil {
 		m.viewport.SetContent(fmt.Sprint("An error occured:", m.err))
+		return m
+	} else if len(m.collapsed) > 0 {
+		m.viewport.SetContent(fillerStyle.Render(m.collapsed))
+		return m
+	} else if m.isSplitView && m.width >= minSplitWidth {
+		m.layout = m.layoutSplit()
 	} else {
-
-		m.viewport.SetContent(m.textBuilder.String())
+		m.layout = m.layoutUnified()
 	}
-
+	m.viewport.SetContent(strings.Repeat("\n", max(m.layout.rows.Rows()-1, 0)))
 	return m
 }
@@ -0,0 +1,206 @@
+package diff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// fold hides a range of lines of the diff behind a placeholder.
+type fold struct {
+	lineRange
+	// hunk is the index of the header of the folded hunk, or -1 for folded context.
+	hunk        int
+	isGenerated bool
+}
+
+func (f fold) placeholder() string {
+	lines := f.end - f.start
+	if f.isGenerated {
+		return fmt.Sprintf("  ⋯ %d lines of a generated file folded, press L to load", lines)
+	}
+	if f.hunk < 0 {
+		return fmt.Sprintf("  ⋯ %d unchanged lines", lines)
+	}
+	return fmt.Sprintf("  ⋯ %d lines folded", lines)
+}
+
+// folds are the folds of a diff with the fold hiding each line.
+type folds struct {
+	list []fold
+	// hiddenBy is the index of the fold of each line, or -1 for visible lines.
+	hiddenBy []int
+}
+
+// newFolds folds the generated files, the hunks with the given headers and,
+// if enabled, all long runs of context, which weren't expanded.
+func newFolds(
+	structure structure,
+	generated []lineRange,
+	foldedHunks map[int]bool,
+	isContextFolded bool,
+	expandedContext map[int]bool,
+) folds {
+	var (
+		list     []fold
+		lastLine int
+	)
+	for _, lines := range generated {
+		lastLine = max(lastLine, lines.end)
+		list = append(list, fold{lineRange: lines, hunk: -1, isGenerated: true})
+	}
+	for _, hunk := range structure.hunks {
+		lastLine = max(lastLine, hunk.end)
+		if foldedHunks[hunk.header] && hunk.end > hunk.header+1 {
+			list = append(list, fold{lineRange: lineRange{start: hunk.header + 1, end: hunk.end}, hunk: hunk.header})
+		}
+	}
+	if isContextFolded {
+		for _, context := range structure.longContext {
+			if !expandedContext[context.start] {
+				list = append(list, fold{lineRange: context, hunk: -1})
+			}
+		}
+	}
+
+	hiddenBy := make([]int, lastLine)
+	for i := range hiddenBy {
+		hiddenBy[i] = -1
+	}
+	// Generated files and folded hunks take precedence over the folds inside of them.
+	for i := len(list) - 1; i >= 0; i-- {
+		for line := list[i].start; line < list[i].end; line++ {
+			hiddenBy[line] = i
+		}
+	}
+	return folds{list: list, hiddenBy: hiddenBy}
+}
+
+// hidingFold returns the index of the fold, which hides the line.
+func (f folds) hidingFold(line int) (int, bool) {
+	if line < 0 || line >= len(f.hiddenBy) || f.hiddenBy[line] < 0 {
+		return 0, false
+	}
+	return f.hiddenBy[line], true
+}
+
+// scrollTarget is the line of the view, which was scrolled to the top.
+// The view may not scroll that far, e.g. if the line is close to the end.
+type scrollTarget struct {
+	offset, yOffset int
+}
+
+// scrollTo scrolls the line of the view to the top, as far as possible.
+func (m Model) scrollTo(offset int) Model {
+	m.viewport.SetYOffset(offset)
+	m.scrollTarget = &scrollTarget{offset: offset, yOffset: m.viewport.YOffset}
+	return m
+}
+
+// top returns the line of the view at the top. It is the line, which was
+// scrolled to, as long as the view didn't scroll since.
+func (m Model) top() int {
+	if m.scrollTarget != nil && m.scrollTarget.yOffset == m.viewport.YOffset {
+		return m.scrollTarget.offset
+	}
+	return m.viewport.YOffset
+}
+
+// currentHunk returns the index of the last hunk, whose header is at or above the top of the view.
+func (m Model) currentHunk() (int, bool) {
+	if len(m.structure.hunks) == 0 {
+		return 0, false
+	}
+	var (
+		current = 0
+		top     = m.top()
+	)
+	for i, hunk := range m.structure.hunks {
+		if m.lineOffset(hunk.header) > top {
+			break
+		}
+		current = i
+	}
+	return current, true
+}
+
+// scrollToNext scrolls to the first of the lines below the top of the view, or
+// above it, if not forward. The lines are sorted indices of lines of the diff.
+func (m Model) scrollToNext(lines []int, isForward bool) Model {
+	if !isForward {
+		lines = slices.Clone(lines)
+		slices.Reverse(lines)
+	}
+	top := m.top()
+	for _, line := range lines {
+		offset := m.lineOffset(line)
+		if (isForward && offset > top) || (!isForward && offset < top) {
+			return m.scrollTo(offset)
+		}
+	}
+	return m
+}
+
+func (m Model) hunkHeaders() []int {
+	headers := make([]int, len(m.structure.hunks))
+	for i, hunk := range m.structure.hunks {
+		headers[i] = hunk.header
+	}
+	return headers
+}
+
+// toggleHunkFold folds or unfolds the current hunk and keeps its header at the top.
+func (m Model) toggleHunkFold() Model {
+	current, ok := m.currentHunk()
+	if !ok {
+		return m
+	}
+	header := m.structure.hunks[current].header
+
+	foldedHunks := maps.Clone(m.foldedHunks)
+	if foldedHunks[header] {
+		delete(foldedHunks, header)
+	} else {
+		if foldedHunks == nil {
+			foldedHunks = make(map[int]bool)
+		}
+		foldedHunks[header] = true
+	}
+	m.foldedHunks = foldedHunks
+
+	return m.render().scrollTo(m.lineOffset(header))
+}
+
+// toggleContextFold folds or unfolds all long runs of context.
+func (m Model) toggleContextFold() Model {
+	m.isContextFolded = !m.isContextFolded
+	m.expandedContext = nil
+	return m.render()
+}
+
+// unfold unfolds the fold, which hides the line, e.g. to show a match of the search.
+func (m Model) unfold(line int) Model {
+	index, ok := m.folds.hidingFold(line)
+	if !ok {
+		return m
+	}
+
+	fold := m.folds.list[index]
+	if fold.isGenerated {
+		return m.loadAnyway().unfold(line)
+	}
+	if fold.hunk >= 0 {
+		foldedHunks := maps.Clone(m.foldedHunks)
+		delete(foldedHunks, fold.hunk)
+		m.foldedHunks = foldedHunks
+	} else {
+		expandedContext := maps.Clone(m.expandedContext)
+		if expandedContext == nil {
+			expandedContext = make(map[int]bool)
+		}
+		expandedContext[fold.start] = true
+		m.expandedContext = expandedContext
+	}
+	// The line may be hidden by another fold, e.g. context inside of a folded hunk.
+	return m.render().unfold(line)
+}
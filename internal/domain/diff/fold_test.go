@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// twoFileDiff contains two files with two hunks each. The comments are the indices of the lines.
+const twoFileDiff = "diff --git a/a.txt b/a.txt\n" + // 0
+	"--- a/a.txt\n" +
+	"+++ b/a.txt\n" +
+	"@@ -1,2 +1,2 @@\n" + // 3
+	"-a\n" +
+	"+A\n" +
+	" b\n" +
+	"@@ -10,2 +10,2 @@\n" + // 7
+	" c\n" +
+	"-d\n" +
+	"+D\n" +
+	"diff --git a/b.txt b/b.txt\n" + // 11
+	"--- a/b.txt\n" +
+	"+++ b/b.txt\n" +
+	"@@ -1,2 +1,2 @@\n" + // 14
+	"-e\n" +
+	"+E\n" +
+	" f\n" +
+	"@@ -20 +20 @@\n" + // 18
+	"-g\n" +
+	"+G\n"
+
+func newTestModel(rawDiff string, isSplitView bool) Model {
+	m, _ := New().UpdateFocus(true)
+	m.isSplitView = isSplitView
+	return m.SetSize(120, 5).SetContent(rawDiff, nil)
+}
+
+func pressKeys(m Model, keys ...string) Model {
+	for _, k := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	return m
+}
+
+func TestHunkAndFileNavigation(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		expectTop int
+	}{
+		{name: "next hunk from the top", keys: []string{"]"}, expectTop: 3},
+		{name: "next hunk in the next file", keys: []string{"]", "]", "]"}, expectTop: 14},
+		{name: "next hunk at the last hunk", keys: []string{"]", "]", "]", "]", "]", "]"}, expectTop: 18},
+		{name: "previous hunk at the top", keys: []string{"["}, expectTop: 0},
+		{name: "previous hunk at the first hunk", keys: []string{"]", "[", "["}, expectTop: 3},
+		{name: "previous hunk from the last hunk", keys: []string{"]", "]", "]", "]", "["}, expectTop: 14},
+		{name: "next file from the top", keys: []string{"}"}, expectTop: 11},
+		{name: "next file at the last file", keys: []string{"}", "}"}, expectTop: 11},
+		{name: "previous file at the top", keys: []string{"{"}, expectTop: 0},
+		{name: "previous file from the last file", keys: []string{"}", "{"}, expectTop: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := pressKeys(newTestModel(twoFileDiff, false), test.keys...)
+			if top := m.top(); top != test.expectTop {
+				t.Errorf("Expected line %d at the top, got %d", test.expectTop, top)
+			}
+		})
+	}
+}
+
+// A folded hunk ends before the header of the next file, which stays visible.
+func TestFoldHunkAtFileBoundary(t *testing.T) {
+	tests := []struct {
+		name        string
+		isSplitView bool
+		// expectOffset is the line of the view, which shows the header of the next file.
+		expectOffset int
+	}{
+		{name: "unified view", expectOffset: 9},
+		// The paired lines of the first hunk share a row.
+		{name: "split view", isSplitView: true, expectOffset: 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Folds the last hunk of the first file.
+			m := pressKeys(newTestModel(twoFileDiff, test.isSplitView), "]", "]", "z")
+
+			for line := 8; line < 11; line++ {
+				if _, ok := m.folds.hidingFold(line); !ok {
+					t.Errorf("Expected line %d of the hunk to be folded", line)
+				}
+			}
+			for _, line := range []int{7, 11, 14} {
+				if _, ok := m.folds.hidingFold(line); ok {
+					t.Errorf("Expected line %d outside of the hunk to be visible", line)
+				}
+			}
+			// The header of the hunk is followed by the placeholder of the fold.
+			if offset := m.lineOffset(11); offset != test.expectOffset {
+				t.Errorf("Expected the next file at line %d of the view, got %d", test.expectOffset, offset)
+			}
+			if m = pressKeys(m, "}"); m.top() != test.expectOffset {
+				t.Errorf("Expected to jump to the next file at line %d of the view, got %d", test.expectOffset, m.top())
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+)
+
+const maxContextLines = 99
+
+// renameThresholds are the thresholds to cycle through. Zero is git's default.
+var renameThresholds = []int{0, 30, 70, 90}
+
+// FormatChangedMsg is sent after the format was changed by the user.
+// The diff should be loaded again with the new format.
+type FormatChangedMsg struct {
+	Format git.DiffFormat
+}
+
+// WithFormat enables the keys to change the format of the diff.
+// The format is shown in the title.
+func (m Model) WithFormat(format git.DiffFormat) Model {
+	m.format = format
+	m.keys.ignoreAllSpace.SetEnabled(true)
+	m.keys.ignoreSpace.SetEnabled(true)
+	m.keys.ignoreBlank.SetEnabled(true)
+	m.keys.moreContext.SetEnabled(true)
+	m.keys.lessContext.SetEnabled(true)
+	m.keys.algorithm.SetEnabled(true)
+	m.keys.renames.SetEnabled(true)
+	m.keys.functionContext.SetEnabled(true)
+	return m
+}
+
+// updateFormat changes the format, if the key is a key to change it.
+func (m Model) updateFormat(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	format := m.format
+	switch {
+	case key.Matches(msg, m.keys.ignoreAllSpace):
+		format.Whitespace = format.Whitespace.Toggle(git.WhitespaceIgnoreAll)
+	case key.Matches(msg, m.keys.ignoreSpace):
+		format.Whitespace = format.Whitespace.Toggle(git.WhitespaceIgnoreChange)
+	case key.Matches(msg, m.keys.ignoreBlank):
+		format.Whitespace = format.Whitespace.Toggle(git.WhitespaceIgnoreBlankLines)
+	case key.Matches(msg, m.keys.moreContext):
+		format.ContextLines, format.IsContextLinesSet = min(contextLines(format)+1, maxContextLines), true
+	case key.Matches(msg, m.keys.lessContext):
+		format.ContextLines, format.IsContextLinesSet = max(contextLines(format)-1, 0), true
+	case key.Matches(msg, m.keys.algorithm):
+		format.Algorithm = nextAlgorithm(format.Algorithm)
+	case key.Matches(msg, m.keys.renames):
+		format.RenameThreshold = nextRenameThreshold(format.RenameThreshold)
+	case key.Matches(msg, m.keys.functionContext):
+		format.IsFunctionContext = !format.IsFunctionContext
+	default:
+		return m, nil, false
+	}
+
+	m.format = format
+	return m, func() tea.Msg { return FormatChangedMsg{Format: format} }, true
+}
+
+func contextLines(format git.DiffFormat) int {
+	if !format.IsContextLinesSet {
+		return git.DefaultContextLines
+	}
+	return format.ContextLines
+}
+
+// nextAlgorithm cycles through the algorithms. Git's default comes first.
+func nextAlgorithm(algorithm git.DiffAlgorithm) git.DiffAlgorithm {
+	switch algorithm {
+	case "":
+		return git.DiffAlgorithmMyers
+	case git.DiffAlgorithmMyers:
+		return git.DiffAlgorithmPatience
+	case git.DiffAlgorithmPatience:
+		return git.DiffAlgorithmHistogram
+	}
+	return ""
+}
+
+func nextRenameThreshold(threshold int) int {
+	for i, renameThreshold := range renameThresholds {
+		if renameThreshold == threshold {
+			return renameThresholds[(i+1)%len(renameThresholds)]
+		}
+	}
+	return renameThresholds[0]
+}
+
+// describeFormat describes the settings of the format, which differ from git's defaults,
+// mostly by their short flags, e.g. `-w -U5 patience`.
+func describeFormat(format git.DiffFormat) string {
+	var settings []string
+
+	if format.Whitespace.Has(git.WhitespaceIgnoreAll) {
+		settings = append(settings, "-w")
+	}
+	if format.Whitespace.Has(git.WhitespaceIgnoreChange) {
+		settings = append(settings, "-b")
+	}
+	if format.Whitespace.Has(git.WhitespaceIgnoreBlankLines) {
+		settings = append(settings, "--ignore-blank-lines")
+	}
+
+	if format.IsContextLinesSet {
+		settings = append(settings, fmt.Sprintf("-U%d", format.ContextLines))
+	}
+
+	if len(format.Algorithm) > 0 {
+		settings = append(settings, string(format.Algorithm))
+	}
+
+	if format.RenameThreshold > 0 {
+		settings = append(settings, fmt.Sprintf("-M%d%%", format.RenameThreshold))
+	}
+
+	if format.IsFunctionContext {
+		settings = append(settings, "-W")
+	}
+
+	return strings.Join(settings, " ")
+}
@@ -0,0 +1,371 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/syntax"
+	"github.com/michaelhass/gitglance/internal/core/textwrap"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+	"github.com/michaelhass/gitglance/internal/core/worddiff"
+)
+
+var (
+	addedTintStyle   = lipgloss.NewStyle().Background(style.AddedBackgroundColor)
+	removedTintStyle = lipgloss.NewStyle().Background(style.RemovedBackgroundColor)
+
+	tokenStyles = map[syntax.Kind]lipgloss.Style{
+		syntax.Keyword: style.KeywordText,
+		syntax.String:  style.StringText,
+		syntax.Number:  style.NumberText,
+		syntax.Comment: style.CommentText,
+	}
+)
+
+// lineHighlight contains the highlighting of the code of a line, without its prefix.
+type lineHighlight struct {
+	// kinds are the syntax kinds of the runes, if the language of the file is known.
+	kinds []syntax.Kind
+	// emphasized marks the runes of the words, which changed compared to the paired line.
+	emphasized []bool
+}
+
+func (h lineHighlight) isSyntaxHighlighted() bool {
+	return h.kinds != nil
+}
+
+// hunkLocation is the index of a file in the diff and of a hunk in the file.
+type hunkLocation struct {
+	file, hunk int
+}
+
+// highlights contains the highlighting of the lines in the hunks of a diff.
+// Each hunk is highlighted once, when one of its lines is rendered for the first time,
+// so that only the visible parts of large diffs are tokenized.
+type highlights struct {
+	diff  unidiff.Diff
+	hunks []hunkLocation
+	// hunkOf is the index in hunks of the hunk of each line, or -1 for lines outside of hunks.
+	hunkOf        []int
+	isHighlighted []bool
+	languages     map[int]*syntax.Language
+	lines         map[int]lineHighlight
+}
+
+func newHighlights(diff unidiff.Diff) *highlights {
+	result := &highlights{
+		diff:      diff,
+		hunkOf:    make([]int, len(diff.Lines)),
+		languages: make(map[int]*syntax.Language),
+		lines:     make(map[int]lineHighlight),
+	}
+	for i := range result.hunkOf {
+		result.hunkOf[i] = -1
+	}
+	for fileIndex, file := range diff.Files {
+		for hunkIndex, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				result.hunkOf[line.Index] = len(result.hunks)
+			}
+			result.hunks = append(result.hunks, hunkLocation{file: fileIndex, hunk: hunkIndex})
+		}
+	}
+	result.isHighlighted = make([]bool, len(result.hunks))
+	return result
+}
+
+// line returns the highlighting of the line at the index, highlighting its hunk if needed.
+// It reports false, if the line isn't highlighted.
+func (h *highlights) line(index int) (lineHighlight, bool) {
+	if h == nil || index < 0 || index >= len(h.hunkOf) || h.hunkOf[index] < 0 {
+		return lineHighlight{}, false
+	}
+	if hunk := h.hunkOf[index]; !h.isHighlighted[hunk] {
+		h.isHighlighted[hunk] = true
+		h.highlight(h.hunks[hunk])
+	}
+	highlight, ok := h.lines[index]
+	return highlight, ok
+}
+
+// language returns the language of the file. It is detected by the path of the file
+// or a shebang in its first line.
+func (h *highlights) language(fileIndex int) *syntax.Language {
+	if language, ok := h.languages[fileIndex]; ok {
+		return language
+	}
+
+	var (
+		file      = h.diff.Files[fileIndex]
+		firstLine string
+	)
+	// Only the first line of a file may contain a shebang.
+	for _, hunk := range file.Hunks {
+		if index := slices.IndexFunc(hunk.Lines, func(line unidiff.Line) bool { return line.Kind.IsContent() }); index >= 0 {
+			firstLine = hunk.Lines[index].Text
+			break
+		}
+	}
+	language, _ := syntax.Detect(file.Path(), firstLine)
+	h.languages[fileIndex] = language
+	return language
+}
+
+// highlight tokenizes the lines of the hunk and emphasizes the changed words of modified lines.
+func (h *highlights) highlight(location hunkLocation) {
+	var (
+		hunk           = h.diff.Files[location.file].Hunks[location.hunk]
+		language       = h.language(location.file)
+		removed, added []unidiff.Line
+		// The old side of the hunk consists of the context and deleted lines,
+		// the new side of the context and added lines. Each side continues its own state,
+		// e.g. a deleted line, which opens a block comment, doesn't comment out added lines.
+		oldState, newState syntax.State
+	)
+
+	// Removed lines are paired with the lines that were added instead of them.
+	pair := func() {
+		for i := 0; i < min(len(removed), len(added)); i++ {
+			oldChanged, newChanged, ok := worddiff.Diff(expandTabs(removed[i].Text), expandTabs(added[i].Text))
+			if !ok {
+				continue
+			}
+			h.lines[removed[i].Index] = h.lines[removed[i].Index].emphasize(oldChanged)
+			h.lines[added[i].Index] = h.lines[added[i].Index].emphasize(newChanged)
+		}
+		removed, added = nil, nil
+	}
+
+	for _, line := range hunk.Lines {
+		switch line.Kind {
+		case unidiff.Deleted:
+			removed = append(removed, line)
+		case unidiff.Added:
+			added = append(added, line)
+		case unidiff.Context:
+			pair()
+		default:
+			continue
+		}
+
+		if language == nil {
+			continue
+		}
+		var (
+			code   = expandTabs(line.Text)
+			tokens []syntax.Token
+		)
+		switch line.Kind {
+		case unidiff.Deleted:
+			tokens, oldState = language.Tokenize(code, oldState)
+		case unidiff.Added:
+			tokens, newState = language.Tokenize(code, newState)
+		default:
+			// Context lines are highlighted as part of the new side.
+			if oldState != newState {
+				_, oldState = language.Tokenize(code, oldState)
+				tokens, newState = language.Tokenize(code, newState)
+			} else {
+				tokens, newState = language.Tokenize(code, newState)
+				oldState = newState
+			}
+		}
+		highlight := h.lines[line.Index]
+		highlight.kinds = tokenKinds(tokens, len([]rune(code)))
+		h.lines[line.Index] = highlight
+	}
+	pair()
+}
+
+func (h lineHighlight) emphasize(changed []bool) lineHighlight {
+	h.emphasized = changed
+	return h
+}
+
+// newLineRenderer colors the lines of the diff by their kind. Syntax highlighted
+// lines are tinted instead, so that the colors of their tokens stay visible.
+func newLineRenderer(lines []unidiff.Line, highlights *highlights, search search) textwrap.IndexedLineRenderer {
+	return func(index int, line string) textwrap.Renderer {
+		var (
+			kind                     = lineKind(lines, index)
+			highlight, isHighlighted = highlights.line(index)
+			matches                  = search.lineMatches(index)
+		)
+		if !isHighlighted && matches == nil {
+			return lineStyle(kind)
+		}
+		return highlightRenderer{
+			line:       line,
+			highlights: runeHighlights(line, highlight, matches),
+			style:      highlightStyle(kind, highlight),
+			emphasis:   emphasisColor(kind),
+		}
+	}
+}
+
+// lineKind returns the kind of the line at the index, e.g. while the lines are parsed again.
+func lineKind(lines []unidiff.Line, index int) unidiff.LineKind {
+	if index < len(lines) {
+		return lines[index].Kind
+	}
+	return unidiff.Other
+}
+
+func lineStyle(kind unidiff.LineKind) lipgloss.Style {
+	switch kind {
+	case unidiff.Added:
+		return addedTextStyle
+	case unidiff.Deleted:
+		return removedTextStyle
+	case unidiff.FileHeader:
+		return fileHeaderStyle
+	case unidiff.HunkHeader:
+		return hunkHeaderStyle
+	case unidiff.NoNewline, unidiff.Binary:
+		return fillerStyle
+	}
+	return normalTextStyle
+}
+
+func highlightStyle(kind unidiff.LineKind, highlight lineHighlight) lipgloss.Style {
+	if !highlight.isSyntaxHighlighted() {
+		return lineStyle(kind)
+	}
+	switch kind {
+	case unidiff.Added:
+		return addedTintStyle
+	case unidiff.Deleted:
+		return removedTintStyle
+	}
+	return normalTextStyle
+}
+
+func emphasisColor(kind unidiff.LineKind) lipgloss.TerminalColor {
+	if kind == unidiff.Added {
+		return style.AddedEmphasisBackgroundColor
+	}
+	return style.RemovedEmphasisBackgroundColor
+}
+
+// tokenKinds returns the kind of each of the count runes of the tokens.
+func tokenKinds(tokens []syntax.Token, count int) []syntax.Kind {
+	kinds := make([]syntax.Kind, 0, count)
+	for _, token := range tokens {
+		for range token.Text {
+			kinds = append(kinds, token.Kind)
+		}
+	}
+	return kinds
+}
+
+// runeHighlight is the highlighting of a single rune of a line.
+type runeHighlight struct {
+	kind         syntax.Kind
+	isEmphasized bool
+	match        matchKind
+}
+
+// runeHighlights combines the highlighting of the code of the line
+// with the search matches of the whole line. The prefix of the line
+// is neither syntax highlighted nor emphasized.
+func runeHighlights(line string, highlight lineHighlight, matches []matchKind) []runeHighlight {
+	result := make([]runeHighlight, utf8.RuneCountInString(line))
+	for i := range result {
+		if i > 0 && i-1 < len(highlight.kinds) {
+			result[i].kind = highlight.kinds[i-1]
+		}
+		if i > 0 && i-1 < len(highlight.emphasized) {
+			result[i].isEmphasized = highlight.emphasized[i-1]
+		}
+		if i < len(matches) {
+			result[i].match = matches[i]
+		}
+	}
+	return result
+}
+
+// highlightRenderer renders a line of a diff, which was wrapped by textwrap,
+// with the highlighting of its runes.
+type highlightRenderer struct {
+	line       string
+	highlights []runeHighlight
+	style      lipgloss.Style
+	emphasis   lipgloss.TerminalColor
+}
+
+// Render expects the line with line breaks inserted by wrapping.
+func (r highlightRenderer) Render(s ...string) string {
+	var (
+		builder   strings.Builder
+		lineRunes = []rune(r.line)
+		runes     []rune
+		start     int
+		i         int
+	)
+	for _, rune := range strings.Join(s, "") {
+		if rune == '\n' && (i >= len(lineRunes) || lineRunes[i] != '\n') {
+			builder.WriteString(renderHighlights(runes, r.highlights[min(start, len(r.highlights)):], r.style, r.emphasis))
+			builder.WriteString("\n")
+			runes, start = nil, i
+			continue
+		}
+		runes = append(runes, rune)
+		i++
+	}
+	builder.WriteString(renderHighlights(runes, r.highlights[min(start, len(r.highlights)):], r.style, r.emphasis))
+	return builder.String()
+}
+
+// renderHighlights renders the runes with their highlighting on top of the base style.
+// Emphasized runes get the emphasis background. Search matches are rendered
+// above the syntax colors and emphasized words.
+func renderHighlights(
+	runes []rune,
+	highlights []runeHighlight,
+	base lipgloss.Style,
+	emphasis lipgloss.TerminalColor,
+) string {
+	var (
+		builder strings.Builder
+		start   int
+	)
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && highlightAt(highlights, i) == highlightAt(highlights, start) {
+			continue
+		}
+
+		var (
+			highlight    = highlightAt(highlights, start)
+			segmentStyle = base
+		)
+		if tokenStyle, ok := tokenStyles[highlight.kind]; ok {
+			segmentStyle = segmentStyle.Foreground(tokenStyle.GetForeground())
+		}
+		if highlight.isEmphasized {
+			segmentStyle = segmentStyle.Background(emphasis)
+		}
+		switch highlight.match {
+		case otherMatch:
+			segmentStyle = segmentStyle.
+				Foreground(style.SearchMatch.GetForeground()).
+				Background(style.SearchMatch.GetBackground())
+		case currentMatch:
+			segmentStyle = segmentStyle.
+				Foreground(style.CurrentSearchMatch.GetForeground()).
+				Background(style.CurrentSearchMatch.GetBackground())
+		}
+		builder.WriteString(segmentStyle.Render(string(runes[start:i])))
+		start = i
+	}
+	return builder.String()
+}
+
+func highlightAt(highlights []runeHighlight, i int) runeHighlight {
+	if i < len(highlights) {
+		return highlights[i]
+	}
+	return runeHighlight{}
+}
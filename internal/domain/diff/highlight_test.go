@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/michaelhass/gitglance/internal/core/syntax"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+func addedFile(path string, lines ...string) string {
+	diff := fmt.Sprintf("diff --git a/%[1]s b/%[1]s\n--- /dev/null\n+++ b/%[1]s\n@@ -0,0 +1,%[2]d @@\n", path, len(lines))
+	for _, line := range lines {
+		diff += "+" + line + "\n"
+	}
+	return diff
+}
+
+// Identical lines are highlighted by their index, as their tokens depend on the
+// language of their file and on the lines above them.
+func TestHighlightsOfIdenticalLines(t *testing.T) {
+	const line = "# return"
+	diff := unidiff.Parse(
+		addedFile("a.go", line) +
+			addedFile("b.py", line) +
+			addedFile("c.go", "/*", line) +
+			addedFile("d.txt", line),
+	)
+
+	var indices []int
+	for _, l := range diff.Lines {
+		if l.Kind == unidiff.Added && l.Text == line {
+			indices = append(indices, l.Index)
+		}
+	}
+	if len(indices) != 4 {
+		t.Fatalf("Expected 4 identical lines, got %d", len(indices))
+	}
+
+	var (
+		highlights = newHighlights(diff)
+		// The kind of `return`, which is a keyword in Go, but commented out in Python
+		// and inside of the block comment.
+		expect = []syntax.Kind{syntax.Keyword, syntax.Comment, syntax.Comment}
+	)
+	for i, kind := range expect {
+		highlight, ok := highlights.line(indices[i])
+		if !ok || !highlight.isSyntaxHighlighted() {
+			t.Errorf("Expected line %d to be highlighted", indices[i])
+			continue
+		}
+		if highlight.kinds[2] != kind {
+			t.Errorf("Expected kind %d of line %d, got %d", kind, indices[i], highlight.kinds[2])
+		}
+	}
+	if _, ok := highlights.line(indices[3]); ok {
+		t.Errorf("Expected the line of the file without language not to be highlighted")
+	}
+}
+
+// A deleted line, which opens a block comment, only comments out the following lines of the old side.
+func TestHighlightsOfDeletedBlockComment(t *testing.T) {
+	diff := unidiff.Parse("diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		"-/*\n" +
+		"+// comment\n" +
+		"+return nil\n" +
+		" return nil\n" +
+		"-return nil\n",
+	)
+
+	var (
+		highlights = newHighlights(diff)
+		expect     = map[unidiff.LineKind]syntax.Kind{
+			unidiff.Added:   syntax.Keyword,
+			unidiff.Context: syntax.Keyword,
+			unidiff.Deleted: syntax.Comment,
+		}
+	)
+	for _, l := range diff.Lines {
+		if l.Text != "return nil" {
+			continue
+		}
+		highlight, ok := highlights.line(l.Index)
+		if !ok || !highlight.isSyntaxHighlighted() {
+			t.Errorf("Expected line %d to be highlighted", l.Index)
+			continue
+		}
+		if highlight.kinds[0] != expect[l.Kind] {
+			t.Errorf("Expected kind %d of line %d, got %d", expect[l.Kind], l.Index, highlight.kinds[0])
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/michaelhass/gitglance/internal/core/filetype"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+const (
+	// headerSize is the size of the start of a file, which is read to describe it. It contains
+	// the bytes to detect the type and the dimensions of images with large metadata.
+	headerSize = 64 << 10
+	// binaryLinePrefix starts the line, which git shows instead of the changes of a binary file.
+	binaryLinePrefix = "Binary files "
+
+	generatedAttribute = "linguist-generated"
+	diffAttribute      = "diff"
+)
+
+// Inspection contains details about the files of a diff, which git doesn't show,
+// e.g. the sizes of binary files. It is loaded together with the diff, as it runs git.
+type Inspection struct {
+	// files are the details of the files by their path.
+	files map[string]fileDetails
+}
+
+type fileDetails struct {
+	// isGenerated is set by the `linguist-generated` attribute.
+	isGenerated bool
+	// isDiffDisabled is set by the `-diff` attribute, which makes git show the file as binary.
+	isDiffDisabled bool
+	oldBlob        blobSummary
+	newBlob        blobSummary
+}
+
+// blobSummary describes one side of a binary file.
+type blobSummary struct {
+	exists bool
+	size   int64
+	info   filetype.Info
+}
+
+// Inspect loads the gitattributes of the files of the diff and describes its binary files.
+// The content of binary files is read from the work tree, if it isn't stored by git yet.
+func Inspect(rawDiff string) Inspection {
+	var (
+		diff   = unidiff.Parse(normalizedBreaks(rawDiff))
+		result = Inspection{files: make(map[string]fileDetails)}
+		paths  []string
+	)
+	for _, file := range diff.Files {
+		paths = append(paths, file.Path())
+	}
+	attributes, _ := git.Attributes(paths, generatedAttribute, diffAttribute)
+
+	root, _ := git.RootFolder()
+	for _, file := range diff.Files {
+		var (
+			path    = file.Path()
+			details = fileDetails{
+				isGenerated:    isAttributeSet(attributes[path][generatedAttribute]),
+				isDiffDisabled: attributes[path][diffAttribute] == git.AttributeUnset,
+			}
+		)
+		if file.IsBinary {
+			details.oldBlob = inspectBlob(file.OldHash, file.OldPath, root)
+			details.newBlob = inspectBlob(file.NewHash, file.NewPath, root)
+		}
+		if details != (fileDetails{}) {
+			result.files[path] = details
+		}
+	}
+	return result
+}
+
+// isAttributeSet reports whether a boolean attribute is set, e.g. `linguist-generated` or `linguist-generated=true`.
+func isAttributeSet(value string) bool {
+	return value == git.AttributeSet || value == "true"
+}
+
+// inspectBlob describes the blob with the hash or, if git doesn't store it, the file in the work tree.
+func inspectBlob(hash string, path string, root string) blobSummary {
+	if len(hash) == 0 || len(strings.Trim(hash, "0")) == 0 || path == "/dev/null" {
+		return blobSummary{}
+	}
+
+	if size, err := git.BlobSize(hash); err == nil {
+		header, _ := git.BlobHeader(hash, headerSize)
+		return blobSummary{exists: true, size: size, info: filetype.Describe(path, header)}
+	}
+
+	// Changes of the work tree aren't stored by git yet.
+	for _, name := range []string{filepath.Join(root, path), path} {
+		stat, err := os.Stat(name)
+		if err != nil || !stat.Mode().IsRegular() {
+			continue
+		}
+		header, _ := readHeader(name)
+		return blobSummary{exists: true, size: stat.Size(), info: filetype.Describe(path, header)}
+	}
+	return blobSummary{}
+}
+
+// readHeader reads at most the first headerSize bytes of the file.
+func readHeader(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, headerSize))
+}
+
+func (i Inspection) equal(other Inspection) bool {
+	return maps.Equal(i.files, other.files)
+}
+
+// isGenerated reports whether the file is generated.
+func (i Inspection) isGenerated(file unidiff.File) bool {
+	return i.files[file.Path()].isGenerated
+}
+
+// isAllGenerated reports whether the diff only contains generated files.
+func (i Inspection) isAllGenerated(diff unidiff.Diff) bool {
+	for _, file := range diff.Files {
+		if !i.isGenerated(file) {
+			return false
+		}
+	}
+	return len(diff.Files) > 0
+}
+
+// summarizeBinaryFiles replaces the lines, which git shows for binary files, by their summary.
+func (i Inspection) summarizeBinaryFiles(diff unidiff.Diff, rawDiff string) (unidiff.Diff, string) {
+	var lines []string
+	for _, file := range diff.Files {
+		summary, ok := i.binarySummary(file)
+		if !ok {
+			continue
+		}
+		for index := file.Start; index < file.End; index++ {
+			if line := diff.Lines[index]; line.Kind != unidiff.Binary || !strings.HasPrefix(line.Text, binaryLinePrefix) {
+				continue
+			}
+			if lines == nil {
+				lines = strings.Split(normalizedBreaks(rawDiff), "\n")
+				diff.Lines = slices.Clone(diff.Lines)
+			}
+			lines[index] = summary
+			diff.Lines[index].Text = summary
+		}
+	}
+	if lines == nil {
+		return diff, rawDiff
+	}
+	return diff, strings.Join(lines, "\n")
+}
+
+// binarySummary summarizes the changes of a binary file, e.g.
+// `Binary file: 1.2 KiB → 1.5 KiB, image/png, 16×16 → 32×32`.
+// It reports false, if the binary file wasn't inspected.
+func (i Inspection) binarySummary(file unidiff.File) (string, bool) {
+	details, ok := i.files[file.Path()]
+	if !ok || (!details.oldBlob.exists && !details.newBlob.exists) {
+		return "", false
+	}
+
+	parts := []string{describeChange(details.oldBlob, details.newBlob, func(blob blobSummary) string {
+		return formatSize(blob.size)
+	})}
+	if types := describeChange(details.oldBlob, details.newBlob, func(blob blobSummary) string {
+		return blob.info.MIMEType
+	}); len(types) > 0 {
+		parts = append(parts, types)
+	}
+	if dimensions := describeChange(details.oldBlob, details.newBlob, func(blob blobSummary) string {
+		if !blob.info.HasDimensions() {
+			return ""
+		}
+		return fmt.Sprintf("%d×%d", blob.info.Width, blob.info.Height)
+	}); len(dimensions) > 0 {
+		parts = append(parts, dimensions)
+	}
+	if details.isDiffDisabled {
+		parts = append(parts, "diff disabled by gitattributes")
+	}
+	return "Binary file: " + strings.Join(parts, ", "), true
+}
+
+// describeChange describes a property of both sides of a file, e.g. `1 KiB → 2 KiB`.
+// The property is described once, if it didn't change, and not at all, if it is unknown.
+func describeChange(oldBlob, newBlob blobSummary, describe func(blobSummary) string) string {
+	var oldValue, newValue string
+	if oldBlob.exists {
+		oldValue = describe(oldBlob)
+	}
+	if newBlob.exists {
+		newValue = describe(newBlob)
+	}
+
+	switch {
+	case !oldBlob.exists:
+		return newValue
+	case !newBlob.exists:
+		return oldValue
+	case oldValue == newValue:
+		return oldValue
+	case len(oldValue) == 0 || len(newValue) == 0:
+		return oldValue + newValue
+	default:
+		return oldValue + " → " + newValue
+	}
+}
+
+// formatSize formats the size in bytes with binary units, e.g. `1.5 KiB`.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exponent := float64(size)/unit, 0
+	for value >= unit && exponent < 3 {
+		value /= unit
+		exponent++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exponent])
+}
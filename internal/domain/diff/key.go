@@ -3,8 +3,29 @@ package diff
 import "github.com/charmbracelet/bubbles/key"
 
 type KeyMap struct {
-	up   key.Binding
-	down key.Binding
+	up          key.Binding
+	down        key.Binding
+	toggleSplit key.Binding
+	search      key.Binding
+	nextMatch   key.Binding
+	prevMatch   key.Binding
+	nextHunk    key.Binding
+	prevHunk    key.Binding
+	nextFile    key.Binding
+	prevFile    key.Binding
+	foldHunk    key.Binding
+	foldContext key.Binding
+	loadAnyway  key.Binding
+
+	// The keys to change the format are disabled, unless the diff can be loaded again.
+	ignoreAllSpace  key.Binding
+	ignoreSpace     key.Binding
+	ignoreBlank     key.Binding
+	moreContext     key.Binding
+	lessContext     key.Binding
+	algorithm       key.Binding
+	renames         key.Binding
+	functionContext key.Binding
 }
 
 func newDiffKeyMap() KeyMap {
@@ -17,13 +38,125 @@ func newDiffKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "down"),
 		),
+		toggleSplit: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "split view"),
+		),
+		search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		nextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n/⇧+n", "next/prev match"),
+		),
+		prevMatch: key.NewBinding(
+			key.WithKeys("N"),
+		),
+		nextHunk: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]/[", "next/prev hunk"),
+		),
+		prevHunk: key.NewBinding(
+			key.WithKeys("["),
+		),
+		nextFile: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}/{", "next/prev file"),
+		),
+		prevFile: key.NewBinding(
+			key.WithKeys("{"),
+		),
+		foldHunk: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "fold hunk"),
+		),
+		foldContext: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("⇧+z", "fold context"),
+		),
+		loadAnyway: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("⇧+l", "load anyway"),
+		),
+		ignoreAllSpace: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "ignore whitespace"),
+			key.WithDisabled(),
+		),
+		ignoreSpace: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("⇧+e", "ignore space change"),
+			key.WithDisabled(),
+		),
+		ignoreBlank: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("⇧+b", "ignore blank lines"),
+			key.WithDisabled(),
+		),
+		moreContext: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+/-", "context"),
+			key.WithDisabled(),
+		),
+		lessContext: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithDisabled(),
+		),
+		algorithm: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "algorithm"),
+			key.WithDisabled(),
+		),
+		renames: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "rename threshold"),
+			key.WithDisabled(),
+		),
+		functionContext: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("⇧+f", "function context"),
+			key.WithDisabled(),
+		),
 	}
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.up, k.down}
+	return []key.Binding{
+		k.up, k.down, k.toggleSplit, k.search, k.nextMatch,
+		k.nextHunk, k.nextFile, k.foldHunk, k.foldContext, k.loadAnyway,
+		k.ignoreAllSpace, k.ignoreSpace, k.ignoreBlank, k.moreContext, k.algorithm, k.renames, k.functionContext,
+	}
 }
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{}
 }
+
+// searchKeyMap describes the keys while typing a search.
+// All other keys are added to the search.
+type searchKeyMap struct {
+	keep  key.Binding
+	clear key.Binding
+}
+
+func newSearchKeyMap() searchKeyMap {
+	return searchKeyMap{
+		keep: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("⏎", "keep search"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear search"),
+		),
+	}
+}
+
+func (km searchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.keep, km.clear}
+}
+
+func (km searchKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{}
+}
@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/query"
+)
+
+type matchKind byte
+
+const (
+	noMatch matchKind = iota
+	otherMatch
+	currentMatch
+)
+
+// searchMatch is a match of the search in a line of the diff.
+// The start and end are rune indices of the line, including its prefix.
+type searchMatch struct {
+	line, start, end int
+}
+
+// search finds the matches of a regular expression in the lines of the diff.
+// Matches may be wrapped over several lines of the view, as the lines are searched before wrapping.
+type search struct {
+	query     string
+	isTyping  bool
+	isInvalid bool
+	matches   []searchMatch
+	// current is the index of the focused match.
+	current int
+	// matchesByLine are the indices of the matches of each line.
+	matchesByLine map[int][]int
+}
+
+// find searches the diff for the query. The search ignores case, unless the query contains upper case letters.
+func (s search) find(rawDiff string) search {
+	s.matches, s.matchesByLine, s.isInvalid = nil, nil, false
+	if len(s.query) == 0 {
+		return s
+	}
+
+	pattern := s.query
+	if !strings.ContainsFunc(pattern, unicode.IsUpper) {
+		pattern = "(?i)" + pattern
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		s.isInvalid = true
+		return s
+	}
+
+	s.matchesByLine = make(map[int][]int)
+	for i, line := range strings.Split(normalizedLines(rawDiff), "\n") {
+		for _, location := range regex.FindAllStringIndex(line, -1) {
+			if location[0] == location[1] {
+				continue
+			}
+			s.matchesByLine[i] = append(s.matchesByLine[i], len(s.matches))
+			s.matches = append(s.matches, searchMatch{
+				line:  i,
+				start: utf8.RuneCountInString(line[:location[0]]),
+				end:   utf8.RuneCountInString(line[:location[1]]),
+			})
+		}
+	}
+	s.current = min(s.current, max(len(s.matches)-1, 0))
+	return s
+}
+
+func (s search) hasMatches() bool {
+	return len(s.matches) > 0
+}
+
+func (s search) currentMatch() (searchMatch, bool) {
+	if !s.hasMatches() {
+		return searchMatch{}, false
+	}
+	return s.matches[s.current], true
+}
+
+// lineMatches returns the kind of match of each rune of the line.
+// It returns nil, if the line doesn't contain matches.
+func (s search) lineMatches(line int) []matchKind {
+	indices, ok := s.matchesByLine[line]
+	if !ok {
+		return nil
+	}
+
+	end := s.matches[indices[len(indices)-1]].end
+	kinds := make([]matchKind, end)
+	for _, i := range indices {
+		kind := otherMatch
+		if i == s.current {
+			kind = currentMatch
+		}
+		for j := s.matches[i].start; j < s.matches[i].end; j++ {
+			kinds[j] = kind
+		}
+	}
+	return kinds
+}
+
+// title describes the search and its matches, e.g. `/func_ [2/7]`.
+func (s search) title() string {
+	var cursor string
+	if s.isTyping {
+		cursor = "_"
+	}
+
+	switch {
+	case s.isInvalid:
+		return fmt.Sprintf("/%s%s [invalid]", s.query, cursor)
+	case s.hasMatches():
+		return fmt.Sprintf("/%s%s [%d/%d]", s.query, cursor, s.current+1, len(s.matches))
+	default:
+		return fmt.Sprintf("/%s%s [0/0]", s.query, cursor)
+	}
+}
+
+// updateSearch edits the query while typing.
+func (m Model) updateSearch(msg tea.KeyMsg) Model {
+	edited, isTyping := query.Edit(m.search.query, msg)
+	m.search.isTyping = isTyping
+	if edited == m.search.query {
+		return m
+	}
+	return m.setQuery(edited)
+}
+
+// setQuery searches the diff for the query and focuses the first match below the top of the view.
+func (m Model) setQuery(query string) Model {
+	m.search.query = query
+	m.search = m.search.find(m.textBuilder.RawString())
+
+	m.search.current = 0
+	for i, match := range m.search.matches {
+		if m.lineOffset(match.line) >= m.viewport.YOffset {
+			m.search.current = i
+			break
+		}
+	}
+	return m.focusMatch(m.search.current)
+}
+
+// focusMatch focuses the match at the index and scrolls to it, if it isn't visible.
+// The index wraps around at both ends of the matches.
+func (m Model) focusMatch(index int) Model {
+	if m.search.hasMatches() {
+		m.search.current = (index + len(m.search.matches)) % len(m.search.matches)
+	}
+	m = m.render()
+
+	match, ok := m.search.currentMatch()
+	if !ok {
+		return m
+	}
+	m = m.unfold(match.line)
+	offset := m.lineOffset(match.line)
+	if offset < m.viewport.YOffset || offset >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(offset - m.viewport.Height/3)
+	}
+	return m
+}
+
+// lineOffset returns the first line of the view, which shows the line of the diff.
+func (m Model) lineOffset(line int) int {
+	if line < len(m.layout.lineOffsets) {
+		return m.layout.lineOffsets[line]
+	}
+	return 0
+}
+
+// IsCapturingInput reports whether the search is being typed.
+// All keys but the arrow keys are added to the search.
+func (m Model) IsCapturingInput() bool {
+	return m.search.isTyping
+}
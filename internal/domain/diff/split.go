@@ -0,0 +1,262 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+const (
+	// minSplitWidth is the minimum width to show the diff side by side.
+	// Narrower diffs are shown unified.
+	minSplitWidth  = 80
+	splitSeparator = " │ "
+)
+
+var (
+	lineNumberStyle = style.SublteText
+	fillerStyle     = style.SublteText
+)
+
+// splitCell is one side of a row. Cells without line number are blank filler.
+type splitCell struct {
+	number int
+	text   string
+	kind   unidiff.LineKind
+	// index of the line in the diff.
+	index int
+}
+
+func (c splitCell) isFiller() bool {
+	return c.number == 0
+}
+
+// splitRow is a row of the side-by-side view. Rows outside of hunks,
+// e.g. file and hunk headers, span both sides.
+type splitRow struct {
+	left, right    splitCell
+	fullWidth      string
+	fullWidthIndex int
+	fullWidthKind  unidiff.LineKind
+	isFullWidth    bool
+}
+
+// indices returns the indices of the lines of the diff shown in the row.
+func (r splitRow) indices() []int {
+	if r.isFullWidth {
+		return []int{r.fullWidthIndex}
+	}
+	var indices []int
+	for _, cell := range []splitCell{r.left, r.right} {
+		if !cell.isFiller() {
+			indices = append(indices, cell.index)
+		}
+	}
+	return indices
+}
+
+// index returns the index of the first line of the diff shown in the row, or -1.
+func (r splitRow) index() int {
+	if indices := r.indices(); len(indices) > 0 {
+		return indices[0]
+	}
+	return -1
+}
+
+// splitRows aligns the lines of each hunk of the unified diff. Removed lines are
+// shown on the left next to the lines that were added instead of them on the right.
+func splitRows(diff unidiff.Diff) []splitRow {
+	var (
+		rows           []splitRow
+		removed, added []splitCell
+	)
+
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			var row splitRow
+			if i < len(removed) {
+				row.left = removed[i]
+			}
+			if i < len(added) {
+				row.right = added[i]
+			}
+			rows = append(rows, row)
+		}
+		removed, added = nil, nil
+	}
+
+	for _, line := range diff.Lines {
+		switch line.Kind {
+		case unidiff.Deleted:
+			removed = append(removed, splitCell{number: line.OldNumber, text: expandTabs(line.Text), kind: line.Kind, index: line.Index})
+		case unidiff.Added:
+			added = append(added, splitCell{number: line.NewNumber, text: expandTabs(line.Text), kind: line.Kind, index: line.Index})
+		case unidiff.Context:
+			flush()
+			rows = append(rows, splitRow{
+				left:  splitCell{number: line.OldNumber, text: expandTabs(line.Text), kind: line.Kind, index: line.Index},
+				right: splitCell{number: line.NewNumber, text: expandTabs(line.Text), kind: line.Kind, index: line.Index},
+			})
+		default:
+			// E.g. headers and `\ No newline at end of file`, which span both sides.
+			flush()
+			text := expandTabs(line.Text)
+			if line.Kind == unidiff.NoNewline {
+				text = `\` + text
+			}
+			rows = append(rows, splitRow{fullWidth: text, fullWidthIndex: line.Index, fullWidthKind: line.Kind, isFullWidth: true})
+		}
+	}
+	flush()
+	return rows
+}
+
+// splitSizes are the widths of the parts of the rows of the split view.
+type splitSizes struct {
+	width, numberWidth, textWidth int
+}
+
+func newSplitSizes(rows []splitRow, width int) splitSizes {
+	var maxNumber int
+	for _, row := range rows {
+		maxNumber = max(maxNumber, row.left.number, row.right.number)
+	}
+
+	var (
+		numberWidth = len(strconv.Itoa(maxNumber))
+		sideWidth   = (width - lipgloss.Width(splitSeparator)) / 2
+	)
+	return splitSizes{width: width, numberWidth: numberWidth, textWidth: max(sideWidth-numberWidth-1, 1)}
+}
+
+// height returns the number of lines of the view, which show the row.
+// Long lines are wrapped inside of their side.
+func (s splitSizes) height(row splitRow) int {
+	if row.isFullWidth {
+		return wrappedRuneLines(row.fullWidth, s.width)
+	}
+	return max(wrappedRuneLines(row.left.text, s.textWidth), wrappedRuneLines(row.right.text, s.textWidth))
+}
+
+// renderSplitRow renders the row side by side. The other side of a wrapped line is padded,
+// so that both sides stay aligned.
+func renderSplitRow(row splitRow, sizes splitSizes, highlights *highlights, search search) []string {
+	var lines []string
+	if row.isFullWidth {
+		var (
+			highlights = runeHighlights(row.fullWidth, lineHighlight{}, search.lineMatches(row.fullWidthIndex))
+			wrapped    = wrapRunes(row.fullWidth, sizes.width)
+		)
+		for i, line := range wrapped {
+			offset := min(i*sizes.width, len(highlights))
+			lines = append(lines, renderHighlights([]rune(line), highlights[offset:], lineStyle(row.fullWidthKind), nil))
+		}
+		return lines
+	}
+
+	var (
+		left  = wrapRunes(row.left.text, sizes.textWidth)
+		right = wrapRunes(row.right.text, sizes.textWidth)
+	)
+	for i := 0; i < max(len(left), len(right)); i++ {
+		lines = append(
+			lines,
+			renderCell(row.left, left, i, sizes.numberWidth, sizes.textWidth, highlights, search)+
+				fillerStyle.Render(splitSeparator)+
+				renderCell(row.right, right, i, sizes.numberWidth, sizes.textWidth, highlights, search),
+		)
+	}
+	return lines
+}
+
+// renderCell renders the i-th wrapped line of the cell. The line number is
+// only shown next to the first line.
+func renderCell(cell splitCell, wrappedLines []string, i int, numberWidth int, textWidth int, highlights *highlights, search search) string {
+	if cell.isFiller() {
+		return fillerStyle.Render(strings.Repeat(" ", numberWidth+1+textWidth))
+	}
+
+	var number string
+	if i == 0 {
+		number = strconv.Itoa(cell.number)
+	}
+	number = strings.Repeat(" ", numberWidth-len(number)) + number + " "
+
+	var text string
+	if i < len(wrappedLines) {
+		text = wrappedLines[i]
+	}
+	padding := strings.Repeat(" ", max(textWidth-lipgloss.Width(text), 0))
+
+	// The prefix of the line in the unified diff, which is hidden in the split view.
+	prefix := " "
+	switch cell.kind {
+	case unidiff.Added:
+		prefix = "+"
+	case unidiff.Deleted:
+		prefix = "-"
+	}
+
+	var (
+		line         = prefix + cell.text
+		matches      = search.lineMatches(cell.index)
+		highlight, _ = highlights.line(cell.index)
+		textStyle    = highlightStyle(cell.kind, highlight)
+	)
+	if !highlight.isSyntaxHighlighted() && highlight.emphasized == nil && matches == nil {
+		return lineNumberStyle.Render(number) + textStyle.Render(text+padding)
+	}
+
+	// The highlighting of the wrapped line starts after the prefix and the lines before it.
+	var (
+		runes  = runeHighlights(line, highlight, matches)
+		offset = min(1+i*textWidth, len(runes))
+	)
+	return lineNumberStyle.Render(number) +
+		renderHighlights([]rune(text), runes[offset:], textStyle, emphasisColor(cell.kind)) +
+		textStyle.Render(padding)
+}
+
+// wrappedRuneLines returns the number of lines of the text wrapped by wrapRunes.
+func wrappedRuneLines(text string, width int) int {
+	count := utf8.RuneCountInString(text)
+	if count == 0 || width <= 0 {
+		return 1
+	}
+	return (count + width - 1) / width
+}
+
+// wrapRunes splits the text into lines of at most width runes.
+func wrapRunes(text string, width int) []string {
+	runes := []rune(text)
+	if len(runes) == 0 || width <= 0 {
+		return []string{text}
+	}
+
+	var lines []string
+	for start := 0; start < len(runes); start += width {
+		lines = append(lines, string(runes[start:min(start+width, len(runes))]))
+	}
+	return lines
+}
+
+// normalizedLines normalizes the diff like textwrap, so that the lines match the rendered lines.
+func normalizedLines(rawDiff string) string {
+	return expandTabs(normalizedBreaks(rawDiff))
+}
+
+// normalizedBreaks breaks the lines of the diff like textwrap, but keeps the tabs,
+// which git uses to mark the end of paths with spaces. Windows line endings are a single break.
+func normalizedBreaks(rawDiff string) string {
+	rawDiff = strings.ReplaceAll(strings.TrimSuffix(rawDiff, "\n"), "\r\n", "\n")
+	return strings.ReplaceAll(rawDiff, "\r", "\n")
+}
+
+// expandTabs expands tabs like textwrap.
+func expandTabs(text string) string {
+	return strings.ReplaceAll(text, "\t", "    ")
+}
@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+const fileHeader = "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n"
+
+// describeRow describes the row as `<left> | <right>`, e.g. `-2 old | +2 new`.
+// Filler cells are empty and full width rows are described by their text.
+func describeRow(row splitRow) string {
+	if row.isFullWidth {
+		return row.fullWidth
+	}
+	describe := func(cell splitCell, prefix string) string {
+		if cell.isFiller() {
+			return ""
+		}
+		if cell.kind == unidiff.Context {
+			prefix = " "
+		}
+		return fmt.Sprintf("%s%d %s", prefix, cell.number, cell.text)
+	}
+	return describe(row.left, "-") + " | " + describe(row.right, "+")
+}
+
+func TestSplitRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		hunk   string
+		expect []string
+	}{
+		{
+			name: "deletions paired with additions",
+			hunk: "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+			expect: []string{
+				"@@ -1,3 +1,3 @@",
+				" 1 a |  1 a",
+				"-2 b | +2 B",
+				" 3 c |  3 c",
+			},
+		},
+		{
+			name: "more deletions than additions",
+			hunk: "@@ -1,4 +1,2 @@\n-a\n-b\n-c\n+A\n d\n",
+			expect: []string{
+				"@@ -1,4 +1,2 @@",
+				"-1 a | +1 A",
+				"-2 b | ",
+				"-3 c | ",
+				" 4 d |  2 d",
+			},
+		},
+		{
+			name: "more additions than deletions",
+			hunk: "@@ -1,2 +1,4 @@\n-a\n+A\n+B\n+C\n d\n",
+			expect: []string{
+				"@@ -1,2 +1,4 @@",
+				"-1 a | +1 A",
+				" | +2 B",
+				" | +3 C",
+				" 2 d |  4 d",
+			},
+		},
+		{
+			name: "runs separated by context are not paired",
+			hunk: "@@ -1,3 +1,3 @@\n-a\n b\n+c\n",
+			expect: []string{
+				"@@ -1,3 +1,3 @@",
+				"-1 a | ",
+				" 2 b |  1 b",
+				" | +2 c",
+			},
+		},
+		{
+			name: "no newline markers span both sides",
+			hunk: "@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+			expect: []string{
+				"@@ -1 +1 @@",
+				"-1 a | ",
+				`\ No newline at end of file`,
+				" | +1 a",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows := splitRows(unidiff.Parse(fileHeader + test.hunk))
+
+			var descriptions []string
+			// The first rows are the file header.
+			for _, row := range rows[strings.Count(fileHeader, "\n"):] {
+				descriptions = append(descriptions, describeRow(row))
+			}
+			if !reflect.DeepEqual(descriptions, test.expect) {
+				t.Errorf("Expected rows\n%s\ngot\n%s", strings.Join(test.expect, "\n"), strings.Join(descriptions, "\n"))
+			}
+		})
+	}
+}
+
+func TestSplitRowIndices(t *testing.T) {
+	diff := unidiff.Parse(fileHeader + "@@ -1,2 +1,2 @@\n-a\n-b\n+A\n")
+	rows := splitRows(diff)
+
+	expect := [][]int{{0}, {1}, {2}, {3}, {4, 6}, {5}}
+	if len(rows) != len(expect) {
+		t.Fatalf("Expected %d rows, got %d", len(expect), len(rows))
+	}
+	for i, row := range rows {
+		if indices := row.indices(); !reflect.DeepEqual(indices, expect[i]) {
+			t.Errorf("Expected indices %v of row %d, got %v", expect[i], i, indices)
+		}
+	}
+}
+
+// Lines ending with \r\n are single lines, so that the parsed lines match the rendered lines.
+func TestNormalizedBreaksOfCRLF(t *testing.T) {
+	const rawDiff = fileHeader + "@@ -1,2 +1,2 @@\n one\r\n-two\r\n+2\r\n"
+
+	var (
+		m      = newTestModel(rawDiff, false)
+		expect = newTestModel(strings.ReplaceAll(rawDiff, "\r\n", "\n"), false)
+	)
+	if !reflect.DeepEqual(m.parsed.Lines, expect.parsed.Lines) {
+		t.Errorf("Expected parsed lines\n%+v\ngot\n%+v", expect.parsed.Lines, m.parsed.Lines)
+	}
+	if count := m.textBuilder.LineCount(); count != expect.textBuilder.LineCount() {
+		t.Errorf("Expected %d rendered lines, got %d", expect.textBuilder.LineCount(), count)
+	}
+}
@@ -0,0 +1,64 @@
+package diff
+
+import "github.com/michaelhass/gitglance/internal/core/unidiff"
+
+const (
+	// contextEdge is the number of context lines, which stay visible
+	// at both ends of a folded run of context lines.
+	contextEdge = 3
+	// minFoldedContext is the minimum number of context lines to fold.
+	minFoldedContext = 4
+)
+
+// hunk is a hunk of the diff. The lines are indices of the lines of the diff.
+type hunk struct {
+	header int
+	// end is the index after the last line of the hunk.
+	end int
+}
+
+// lineRange is a range of lines of the diff from start to the index before end.
+type lineRange struct {
+	start, end int
+}
+
+// structure is the structure of a unified diff, which may contain several files.
+type structure struct {
+	// files are the indices of the first line of each file, e.g. `diff --git a/x b/x`.
+	files []int
+	hunks []hunk
+	// longContext are long runs of context lines inside of hunks without their edges.
+	longContext []lineRange
+}
+
+// newStructure finds the files, hunks and long runs of context of the diff.
+func newStructure(diff unidiff.Diff) structure {
+	var result structure
+	for _, file := range diff.Files {
+		result.files = append(result.files, file.Start)
+
+		for _, h := range file.Hunks {
+			result.hunks = append(result.hunks, hunk{header: h.Header, end: h.End()})
+
+			contextStart := -1
+			endContext := func(end int) {
+				if contextStart >= 0 && end-contextStart-2*contextEdge >= minFoldedContext {
+					result.longContext = append(result.longContext, lineRange{start: contextStart + contextEdge, end: end - contextEdge})
+				}
+				contextStart = -1
+			}
+			for _, line := range h.Lines {
+				switch line.Kind {
+				case unidiff.Context:
+					if contextStart < 0 {
+						contextStart = line.Index
+					}
+				case unidiff.Added, unidiff.Deleted:
+					endContext(line.Index)
+				}
+			}
+			endContext(h.End())
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/michaelhass/gitglance/internal/core/textwrap"
+)
+
+type blockKind byte
+
+const (
+	// lineBlock is a wrapped line of the unified view.
+	lineBlock blockKind = iota
+	// splitRowBlock is a row of the split view.
+	splitRowBlock
+	// foldBlock is the placeholder of a fold.
+	foldBlock
+)
+
+// block is a part of the view, which is rendered at once, e.g. a line of the diff with its wrapped lines.
+type block struct {
+	kind blockKind
+	// index of the line, the split row or the fold.
+	index int
+}
+
+// layout positions the blocks of the view without rendering them. Only the blocks in
+// the visible part of the view are wrapped and styled, when the view is rendered.
+type layout struct {
+	blocks []block
+	rows   textwrap.Layout
+	// lineOffsets are the first lines of the view, which show each line of the diff.
+	lineOffsets []int
+	splitSizes  splitSizes
+}
+
+func (l *layout) append(b block, rows int) {
+	l.blocks = append(l.blocks, b)
+	l.rows.Append(rows)
+}
+
+// layoutUnified positions the lines of the diff, replacing folded lines by their placeholder.
+func (m Model) layoutUnified() layout {
+	var (
+		count       = m.textBuilder.LineCount()
+		result      = layout{lineOffsets: make([]int, count)}
+		currentFold = -1
+	)
+	for i := range count {
+		if index, ok := m.folds.hidingFold(i); ok {
+			if index != currentFold {
+				currentFold = index
+				result.append(block{kind: foldBlock, index: index}, 1)
+			}
+			result.lineOffsets[i] = result.rows.Rows() - 1
+			continue
+		}
+
+		currentFold = -1
+		result.lineOffsets[i] = result.rows.Rows()
+		result.append(block{kind: lineBlock, index: i}, m.textBuilder.Rows(i))
+	}
+	return result
+}
+
+// layoutSplit positions the rows of the split view, replacing folded rows by their placeholder.
+// Both sides are rendered into the same lines, so they scroll together.
+func (m Model) layoutSplit() layout {
+	var (
+		result      = layout{lineOffsets: make([]int, len(m.parsed.Lines)), splitSizes: newSplitSizes(m.splitRows, m.width-1)}
+		currentFold = -1
+	)
+	for i, row := range m.splitRows {
+		if index, ok := m.folds.hidingFold(row.index()); ok {
+			if index != currentFold {
+				currentFold = index
+				result.append(block{kind: foldBlock, index: index}, 1)
+			}
+			for _, line := range row.indices() {
+				result.lineOffsets[line] = result.rows.Rows() - 1
+			}
+			continue
+		}
+
+		currentFold = -1
+		for _, line := range row.indices() {
+			result.lineOffsets[line] = result.rows.Rows()
+		}
+		result.append(block{kind: splitRowBlock, index: i}, result.splitSizes.height(row))
+	}
+	return result
+}
+
+// renderBlock wraps and styles the lines of the block.
+func (m Model) renderBlock(b block) []string {
+	switch b.kind {
+	case foldBlock:
+		return []string{fillerStyle.Render(m.folds.list[b.index].placeholder())}
+	case splitRowBlock:
+		return renderSplitRow(m.splitRows[b.index], m.layout.splitSizes, m.highlights, m.search)
+	default:
+		return strings.Split(m.textBuilder.Line(b.index), "\n")
+	}
+}
+
+// visibleLines renders the lines of the blocks, which are visible in the viewport.
+func (m Model) visibleLines() []string {
+	var (
+		top    = m.viewport.YOffset
+		bottom = min(top+m.viewport.Height, m.layout.rows.Rows())
+		lines  = make([]string, 0, max(bottom-top, 0))
+	)
+	for i := m.layout.rows.Block(top); i < m.layout.rows.Len() && m.layout.rows.Start(i) < bottom; i++ {
+		start := m.layout.rows.Start(i)
+		for j, line := range m.renderBlock(m.layout.blocks[i]) {
+			if row := start + j; row >= top && row < bottom {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
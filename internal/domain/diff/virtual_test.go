@@ -0,0 +1,170 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/michaelhass/gitglance/internal/core/textwrap"
+	"github.com/michaelhass/gitglance/internal/core/unidiff"
+)
+
+// generatedDiff returns a diff of Go files, whose hunks contain long runs of context,
+// which can be folded, and lines, which are long enough to be wrapped.
+func generatedDiff(files, hunksPerFile int) string {
+	var builder strings.Builder
+	for file := range files {
+		fmt.Fprintf(&builder, "diff --git a/file%[1]d.go b/file%[1]d.go\n--- a/file%[1]d.go\n+++ b/file%[1]d.go\n", file)
+		for hunk := range hunksPerFile {
+			start := hunk*100 + 1
+			fmt.Fprintf(&builder, "@@ -%d,25 +%d,25 @@ func f%d() {\n", start, start, hunk)
+			for i := range 12 {
+				fmt.Fprintf(&builder, " \tvalue := compute(%d) // a comment, which is long enough to be wrapped in narrow views\n", i)
+			}
+			builder.WriteString("-\treturn \"old\"\n+\treturn \"new\"\n")
+			for i := range 12 {
+				fmt.Fprintf(&builder, " \tvalue += %d\n", i)
+			}
+		}
+	}
+	return builder.String()
+}
+
+func newSizedTestModel(rawDiff string, isSplitView bool, width, height int) Model {
+	m := New().WithLimit(Limit{})
+	m.isSplitView = isSplitView
+	return m.SetSize(width, height).SetContent(rawDiff, nil)
+}
+
+// allLines renders the lines of all blocks, like the view did before it was virtualized.
+func allLines(m Model) []string {
+	var lines []string
+	for _, b := range m.layout.blocks {
+		lines = append(lines, m.renderBlock(b)...)
+	}
+	return lines
+}
+
+func TestVisibleLines(t *testing.T) {
+	const height = 7
+	tests := []struct {
+		name            string
+		isSplitView     bool
+		isContextFolded bool
+	}{
+		{name: "unified view"},
+		{name: "unified view with folded context", isContextFolded: true},
+		{name: "split view", isSplitView: true},
+		{name: "split view with folded context", isSplitView: true, isContextFolded: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newSizedTestModel(generatedDiff(2, 2), test.isSplitView, 90, height)
+			if test.isContextFolded {
+				m = m.toggleContextFold()
+			}
+
+			all := allLines(m)
+			if rows := m.layout.rows.Rows(); len(all) != rows {
+				t.Fatalf("Expected the blocks to render the %d rows of the layout, got %d lines", rows, len(all))
+			}
+			// The viewport only contains blank lines, but scrolls through as many lines as the view shows.
+			if count := m.viewport.TotalLineCount(); count != len(all) {
+				t.Errorf("Expected %d blank lines in the viewport, got %d", len(all), count)
+			}
+
+			// Each offset starts inside of a different block, e.g. in the middle of a wrapped line.
+			for offset := 0; offset <= len(all)-height; offset++ {
+				m.viewport.SetYOffset(offset)
+				if got, expect := m.visibleLines(), all[offset:offset+height]; !reflect.DeepEqual(got, expect) {
+					t.Fatalf("Expected the lines\n%s\nat offset %d, got\n%s", strings.Join(expect, "\n"), offset, strings.Join(got, "\n"))
+				}
+			}
+
+			m.viewport.GotoBottom()
+			if offset := m.viewport.YOffset; offset != len(all)-height {
+				t.Errorf("Expected to scroll to offset %d at the bottom, got %d", len(all)-height, offset)
+			}
+			if got, expect := m.visibleLines(), all[len(all)-height:]; !reflect.DeepEqual(got, expect) {
+				t.Errorf("Expected the last lines at the bottom\n%s\ngot\n%s", strings.Join(expect, "\n"), strings.Join(got, "\n"))
+			}
+			if lines := strings.Split(m.View(), "\n"); len(lines) != height {
+				t.Errorf("Expected the view to fill its %d lines, got %d", height, len(lines))
+			}
+		})
+	}
+}
+
+// The view may be taller than the diff, e.g. of a single file.
+func TestVisibleLinesOfShortDiff(t *testing.T) {
+	m := newSizedTestModel(twoFileDiff, false, 90, 40)
+	all := allLines(m)
+
+	if got := m.visibleLines(); !reflect.DeepEqual(got, all) {
+		t.Errorf("Expected all lines\n%s\ngot\n%s", strings.Join(all, "\n"), strings.Join(got, "\n"))
+	}
+	if count := m.viewport.TotalLineCount(); count != len(all) {
+		t.Errorf("Expected %d blank lines in the viewport, got %d", len(all), count)
+	}
+	if m.viewport.GotoBottom(); m.viewport.YOffset != 0 {
+		t.Errorf("Expected not to scroll, got offset %d", m.viewport.YOffset)
+	}
+}
+
+// BenchmarkModel builds, resizes and scrolls the model of a diff of about 50000 lines.
+func BenchmarkModel(b *testing.B) {
+	rawDiff := generatedDiff(500, 4)
+
+	// Eager renders every line of the diff into the viewport, like the view did before it was virtualized.
+	b.Run("unified/Eager", func(b *testing.B) {
+		for b.Loop() {
+			parsed := unidiff.Parse(normalizedBreaks(rawDiff))
+			builder := textwrap.NewBuilder()
+			builder.SetLineLength(160 - 5)
+			builder.SetIndexedLineRenderer(newLineRenderer(parsed.Lines, newHighlights(parsed), search{}))
+			builder.WriteString(rawDiff)
+
+			vp := viewport.New(160, 50)
+			vp.SetContent(builder.String())
+			_ = vp.View()
+		}
+	})
+
+	for _, view := range []struct {
+		name        string
+		isSplitView bool
+	}{{name: "unified"}, {name: "split", isSplitView: true}} {
+		b.Run(view.name+"/SetContent", func(b *testing.B) {
+			for b.Loop() {
+				_ = newSizedTestModel(rawDiff, view.isSplitView, 160, 50).View()
+			}
+		})
+
+		b.Run(view.name+"/SetSize", func(b *testing.B) {
+			var (
+				m     = newSizedTestModel(rawDiff, view.isSplitView, 160, 50)
+				width = 160
+			)
+			for b.Loop() {
+				width = 280 - width
+				_ = m.SetSize(width, 50).View()
+			}
+		})
+
+		b.Run(view.name+"/Scroll", func(b *testing.B) {
+			var (
+				m      = newSizedTestModel(rawDiff, view.isSplitView, 160, 50)
+				rows   = m.layout.rows.Rows()
+				offset int
+			)
+			for b.Loop() {
+				offset = (offset + 997) % rows
+				m.viewport.SetYOffset(offset)
+				_ = m.View()
+			}
+		})
+	}
+}
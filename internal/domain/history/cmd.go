@@ -0,0 +1,71 @@
+// Package history provides ui to browse the commits that changed a file.
+package history
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+)
+
+// ShowDialog shows the history of the file at path in the work tree.
+// Diffs larger than the limit are collapsed.
+func ShowDialog(path string, limit diff.Limit, onClose tea.Cmd) tea.Cmd {
+	return dialog.Show(NewDialogContent(path, limit), onClose, dialog.FullScreenDisplayMode)
+}
+
+type loadedMsg struct {
+	commits []git.FileCommit
+	err     error
+}
+
+func load(path string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := git.FileHistory(path)
+		return loadedMsg{commits: commits, err: err}
+	}
+}
+
+// diffLoadedMsg contains the diff of the file at a commit
+// or, if isWorkTreeDiff is set, the diff of the work tree since the commit.
+type diffLoadedMsg struct {
+	commit         git.FileCommit
+	isWorkTreeDiff bool
+	diff           string
+	inspection     diff.Inspection
+	err            error
+}
+
+func loadCommitDiff(commit git.FileCommit) tea.Cmd {
+	return func() tea.Msg {
+		rawDiff, err := git.FileCommitDiff(commit)
+		return diffLoadedMsg{commit: commit, diff: rawDiff, inspection: diff.Inspect(rawDiff), err: err}
+	}
+}
+
+func loadWorkTreeDiff(commit git.FileCommit, path string) tea.Cmd {
+	return func() tea.Msg {
+		rawDiff, err := git.DiffWorkTreeWithFileCommit(commit, path)
+		return diffLoadedMsg{commit: commit, isWorkTreeDiff: true, diff: rawDiff, inspection: diff.Inspect(rawDiff), err: err}
+	}
+}
+
+// workTreeDiffMsg requests to diff the work tree against the commit.
+type workTreeDiffMsg struct {
+	commit git.FileCommit
+}
+
+func diffWorkTree(commit git.FileCommit) tea.Cmd {
+	return func() tea.Msg {
+		return workTreeDiffMsg{commit: commit}
+	}
+}
+
+func showCommit(commit git.FileCommit, limit diff.Limit) tea.Cmd {
+	title := fmt.Sprintf("Commit %s", commit.ShortHash())
+	return diff.ShowDialog(title, limit, func() (string, error) {
+		return git.ShowCommit(commit.Hash)
+	}, nil)
+}
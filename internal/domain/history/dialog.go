@@ -0,0 +1,215 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/container"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+	"github.com/michaelhass/gitglance/internal/domain/tag"
+)
+
+type section byte
+
+const (
+	commitsSection section = iota
+	diffSection
+)
+
+const (
+	commitsWidthFactor       float32 = 0.45
+	sectionsHorizontalMargin int     = 1
+)
+
+// DialogContent shows the commits that changed a file on the left
+// and the changes of the file in the focused commit on the right.
+type DialogContent struct {
+	path           string
+	sections       [2]container.Model
+	focusedSection section
+	keys           KeyMap
+}
+
+func NewDialogContent(path string, limit diff.Limit) DialogContent {
+	commitKeys := newCommitKeyMap()
+	itemHandler := func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.FocusItemMsg:
+			if item, ok := msg.Item.(commitItem); ok {
+				return loadCommitDiff(item.commit)
+			}
+		case list.SelectItemMsg:
+			if item, ok := msg.Item.(commitItem); ok {
+				return showCommit(item.commit, limit)
+			}
+		case list.CustomItemMsg:
+			item, ok := msg.Item.(commitItem)
+			if !ok {
+				return nil
+			}
+			switch {
+			case key.Matches(msg.KeyMsg, commitKeys.diffWorkTree):
+				return diffWorkTree(item.commit)
+			case key.Matches(msg.KeyMsg, commitKeys.tag):
+				return tag.ShowCreateDialog(item.commit.Hash, nil)
+			}
+		}
+		return nil
+	}
+
+	commits := list.NewContainer(list.New(fmt.Sprintf("History %s", path), itemHandler, commitKeys.KeyMap))
+	commits, _ = commits.UpdateFocus(true)
+
+	return DialogContent{
+		path: path,
+		sections: [2]container.Model{
+			commits,
+			container.New(diff.NewContent(diff.New().WithLimit(limit))),
+		},
+		keys: newKeyMap(),
+	}
+}
+
+func (dc DialogContent) Init() tea.Cmd {
+	return load(dc.path)
+}
+
+func (dc DialogContent) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case loadedMsg:
+		var cmd tea.Cmd
+		dc, cmd = dc.setCommits(msg)
+		return dc, cmd
+	case diffLoadedMsg:
+		if commit, ok := dc.focusedCommit(); ok && commit.Hash == msg.commit.Hash {
+			dc = dc.setDiff(msg)
+		}
+		return dc, nil
+	case workTreeDiffMsg:
+		return dc, loadWorkTreeDiff(msg.commit, dc.path)
+	case tea.KeyMsg:
+		if dc.IsCapturingInput() {
+			break
+		}
+		switch {
+		case key.Matches(msg, dc.keys.left):
+			dc.focusedSection = commitsSection
+		case key.Matches(msg, dc.keys.right):
+			dc.focusedSection = diffSection
+		}
+	}
+
+	for i, section := range dc.sections {
+		updatedSection, cmd := section.UpdateFocus(i == int(dc.focusedSection))
+		cmds = append(cmds, cmd)
+
+		updatedSection, cmd = updatedSection.Update(msg)
+		cmds = append(cmds, cmd)
+
+		dc.sections[i] = updatedSection
+	}
+
+	return dc, tea.Batch(cmds...)
+}
+
+func (dc DialogContent) View() string {
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		dc.sections[commitsSection].View(),
+		" ",
+		dc.sections[diffSection].View(),
+	)
+}
+
+func (dc DialogContent) SetSize(width, height int) dialog.Content {
+	var (
+		commitsWidth = int(float32(width) * commitsWidthFactor)
+		diffWidth    = width - commitsWidth - sectionsHorizontalMargin
+	)
+
+	dc.sections[commitsSection] = dc.sections[commitsSection].SetSize(commitsWidth, height)
+	dc.sections[diffSection] = dc.sections[diffSection].SetSize(diffWidth, height)
+	return dc
+}
+
+func (dc DialogContent) Help() []key.Binding {
+	var keys []key.Binding
+	if keyMap := dc.sections[dc.focusedSection].Content().KeyMap(); keyMap != nil {
+		keys = append(keys, keyMap.ShortHelp()...)
+	}
+	if dc.IsCapturingInput() {
+		return keys
+	}
+
+	if dc.focusedSection == diffSection {
+		keys = append(keys, dc.keys.left)
+	} else {
+		keys = append(keys, dc.keys.right)
+	}
+	return keys
+}
+
+func (dc DialogContent) IsCapturingInput() bool {
+	return dc.sections[dc.focusedSection].IsCapturingInput()
+}
+
+func (dc DialogContent) focusedCommit() (git.FileCommit, bool) {
+	content, ok := dc.sections[commitsSection].Content().(list.ContainerContent)
+	if !ok {
+		return git.FileCommit{}, false
+	}
+	item, err := content.FocusedItem()
+	if err != nil {
+		return git.FileCommit{}, false
+	}
+	commitItem, ok := item.(commitItem)
+	return commitItem.commit, ok
+}
+
+func (dc DialogContent) setCommits(msg loadedMsg) (DialogContent, tea.Cmd) {
+	content, ok := dc.sections[commitsSection].Content().(list.ContainerContent)
+	if !ok {
+		return dc, nil
+	}
+
+	items := make([]list.Item, len(msg.commits))
+	for i, commit := range msg.commits {
+		items[i] = commitItem{commit: commit}
+	}
+
+	// Setting the items requests the diff of the focused commit.
+	var cmd tea.Cmd
+	content.Model, cmd = content.SetItems(items)
+	dc.sections[commitsSection] = dc.sections[commitsSection].SetContent(content)
+	if msg.err != nil || len(items) == 0 {
+		dc = dc.setDiff(diffLoadedMsg{err: msg.err})
+	}
+	return dc, cmd
+}
+
+func (dc DialogContent) setDiff(msg diffLoadedMsg) DialogContent {
+	content, ok := dc.sections[diffSection].Content().(diff.ContainerContent)
+	if !ok {
+		return dc
+	}
+	content.Model = content.SetInspectedContent(msg.diff, msg.inspection, msg.err).SetTitle(diffTitle(msg))
+	dc.sections[diffSection] = dc.sections[diffSection].SetContent(content)
+	return dc
+}
+
+func diffTitle(msg diffLoadedMsg) string {
+	switch {
+	case len(msg.commit.Hash) == 0:
+		return "Diff"
+	case msg.isWorkTreeDiff:
+		return fmt.Sprintf("Diff work tree ↔ %s", msg.commit.ShortHash())
+	}
+	return fmt.Sprintf("Diff %s", msg.commit.ShortHash())
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/textwrap"
+	"github.com/michaelhass/gitglance/internal/core/ui/style"
+)
+
+const (
+	authorWidth = 14
+	dateFormat  = "2006-01-02"
+)
+
+type commitItem struct {
+	commit git.FileCommit
+}
+
+// Render shows the commit and, if the file was renamed in it, its paths.
+func (item commitItem) Render() string {
+	commit := item.commit
+	line := fmt.Sprintf(
+		"%s %s %-*s %s",
+		commit.ShortHash(),
+		commit.Date.Format(dateFormat),
+		authorWidth,
+		textwrap.Truncate(commit.Author, authorWidth),
+		commit.Subject,
+	)
+	if commit.IsRenamed() {
+		line += style.SublteText.Render(fmt.Sprintf(" (%s → %s)", commit.OldPath, commit.Path))
+	}
+	return line
+}
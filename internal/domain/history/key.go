@@ -0,0 +1,44 @@
+package history
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+)
+
+type KeyMap struct {
+	left  key.Binding
+	right key.Binding
+}
+
+func newKeyMap() KeyMap {
+	return KeyMap{
+		left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+		),
+		right: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+		),
+	}
+}
+
+// commitKeyMap contains the list keys and the custom keys of a focused commit.
+type commitKeyMap struct {
+	list.KeyMap
+	diffWorkTree key.Binding
+	tag          key.Binding
+}
+
+func newCommitKeyMap() commitKeyMap {
+	keyMap := list.NewKeyMap("", "show commit", "")
+	keyMap.All.SetEnabled(false)
+	keyMap.Edit.SetEnabled(false)
+	keyMap.Delete.SetEnabled(false)
+	var (
+		diffWorkTree = key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "diff work tree"))
+		tag          = key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tag commit"))
+	)
+	keyMap.CustomKeys = []key.Binding{diffWorkTree, tag}
+	return commitKeyMap{KeyMap: keyMap, diffWorkTree: diffWorkTree, tag: tag}
+}
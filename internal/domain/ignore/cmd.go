@@ -0,0 +1,174 @@
+// Package ignore provides ui to add untracked files to an ignore file.
+package ignore
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/err"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/form"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+)
+
+type CmdExecuted struct {
+	Patterns []string
+	err      error
+}
+
+func (ce CmdExecuted) Err() error {
+	return ce.err
+}
+
+func (ce CmdExecuted) ErrorTitle() string {
+	return "Ignore error"
+}
+
+func (ce CmdExecuted) ErrorDescription() string {
+	return ce.err.Error()
+}
+
+func executionErrHandler(msg tea.Msg) tea.Cmd {
+	if errMsg, ok := msg.(err.Msg); ok && errMsg.Err() != nil {
+		errDialogContent := info.NewDialogContentWithErrMsg(errMsg)
+		return dialog.Show(errDialogContent, nil, dialog.CenterDisplayMode)
+	}
+	return nil
+}
+
+// Add appends the patterns to the ignore file for the path.
+func Add(patterns []string, file git.IgnoreFile, path string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.AddIgnorePatterns(patterns, file, path)
+		return CmdExecuted{Patterns: patterns, err: err}
+	}
+}
+
+const (
+	optionFieldKey        = "option"
+	customPatternFieldKey = "customPattern"
+)
+
+// option is a choice of the patterns and the ignore file to add them to.
+// Patterns of nested ignore files are relative to their directory, so each
+// option shows exactly the patterns, which are written to the file.
+type option struct {
+	file     git.IgnoreFile
+	patterns []string
+	// isCustom uses the custom pattern instead of the patterns.
+	isCustom bool
+}
+
+// ShowDialog shows a form to choose patterns to ignore the paths and the file to add them to.
+// The paths are relative to the root of the work tree. Directories end with a slash.
+func ShowDialog(paths []string, onClose tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		trackedPaths, err := git.TrackedPaths(paths)
+		if err != nil {
+			content := info.NewDialogContent(info.New("Ignore error", err.Error()))
+			return dialog.Show(content, onClose, dialog.CenterDisplayMode)()
+		}
+		dc := form.NewDialogContent(newForm(paths, trackedPaths)).WithErrHandler(executionErrHandler)
+		return dialog.Show(dc, onClose, dialog.CenterDisplayMode)()
+	}
+}
+
+// newForm creates the form to ignore the paths, which warns about the tracked paths.
+func newForm(paths []string, trackedPaths []string) form.Model {
+	var (
+		path      = paths[0]
+		files     = []git.IgnoreFile{git.RootIgnoreFile}
+		filePaths = []string{git.IgnoreFilePath(git.RootIgnoreFile, path)}
+	)
+	// The nested ignore file is only offered, if all paths share it.
+	if nestedPath, ok := sharedNestedIgnoreFilePath(paths); ok && nestedPath != filePaths[0] {
+		files = append(files, git.NestedIgnoreFile)
+		filePaths = append(filePaths, nestedPath)
+	}
+	files = append(files, git.ExcludeIgnoreFile)
+	filePaths = append(filePaths, git.IgnoreFilePath(git.ExcludeIgnoreFile, path))
+
+	var (
+		options     []option
+		optionNames []string
+	)
+	for i, file := range files {
+		for _, patternType := range []git.IgnorePatternType{
+			git.PathIgnorePattern,
+			git.DirectoryIgnorePattern,
+			git.ExtensionIgnorePattern,
+		} {
+			if patterns, err := ignorePatterns(patternType, file, paths); err == nil {
+				options = append(options, option{file: file, patterns: patterns})
+				optionNames = append(optionNames, fmt.Sprintf("%s in %s", strings.Join(patterns, " "), filePaths[i]))
+			}
+		}
+	}
+	// The last options add the custom pattern.
+	for i, file := range files {
+		options = append(options, option{file: file, isCustom: true})
+		optionNames = append(optionNames, fmt.Sprintf("custom in %s", filePaths[i]))
+	}
+
+	description := path
+	if len(paths) > 1 {
+		description = fmt.Sprintf("%d files", len(paths))
+	}
+	message := fmt.Sprintf("How do you want to ignore %s?", description)
+	if len(trackedPaths) > 0 {
+		message += fmt.Sprintf(
+			"\n\nWarning: %s tracked. Ignoring has no effect on tracked files until they are removed from the index with `git rm --cached`.",
+			describeTracked(trackedPaths),
+		)
+	}
+
+	return form.New("Ignore", message).
+		WithChoice(optionFieldKey, "Pattern", optionNames, 0).
+		WithTextField(customPatternFieldKey, "Custom pattern", "Pattern...", "").
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			option := options[values.Choice(optionFieldKey)]
+			if option.isCustom {
+				return Add([]string{values.Text(customPatternFieldKey)}, option.file, path)
+			}
+			return Add(option.patterns, option.file, path)
+		})
+}
+
+// ignorePatterns returns the distinct patterns of the type for all paths.
+// It fails, if there is no pattern of the type for any of the paths.
+func ignorePatterns(patternType git.IgnorePatternType, file git.IgnoreFile, paths []string) ([]string, error) {
+	var (
+		patterns []string
+		isAdded  = make(map[string]bool)
+	)
+	for _, path := range paths {
+		pattern, err := git.IgnorePattern(patternType, file, path)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdded[pattern] {
+			isAdded[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, nil
+}
+
+func sharedNestedIgnoreFilePath(paths []string) (string, bool) {
+	nestedPath := git.IgnoreFilePath(git.NestedIgnoreFile, paths[0])
+	for _, path := range paths[1:] {
+		if git.IgnoreFilePath(git.NestedIgnoreFile, path) != nestedPath {
+			return "", false
+		}
+	}
+	return nestedPath, true
+}
+
+func describeTracked(paths []string) string {
+	if len(paths) == 1 {
+		return fmt.Sprintf("%s is", paths[0])
+	}
+	return fmt.Sprintf("%s are", strings.Join(paths, ", "))
+}
@@ -0,0 +1,87 @@
+// Package remote provides ui to fetch, pull and push.
+package remote
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/confirm"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/progress"
+)
+
+// ShowFetch fetches all remotes and prunes stale remote-tracking branches.
+func ShowFetch(onClose tea.Cmd) tea.Cmd {
+	return showProgress("Fetch", func(report func(string)) error {
+		return git.Fetch(git.FetchOptions{All: true, Prune: true}, report)
+	}, onClose)
+}
+
+// ShowPull pulls the upstream of the current branch.
+// It merges or rebases as configured by pull.rebase.
+func ShowPull(onClose tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		title := fmt.Sprintf("Pull (%s)", git.ConfiguredPullMode())
+		return showProgress(title, func(report func(string)) error {
+			return git.Pull(git.PullOptions{Mode: git.PullModeDefault}, report)
+		}, onClose)()
+	}
+}
+
+// ShowPush pushes the current branch. The upstream is set on the first push.
+func ShowPush(onClose tea.Cmd) tea.Cmd {
+	return showProgress("Push", func(report func(string)) error {
+		return push(false, report)
+	}, onClose)
+}
+
+// ShowForcePushConfirmation asks to confirm a push with `--force-with-lease`
+// before executing it.
+func ShowForcePushConfirmation(onClose tea.Cmd) tea.Cmd {
+	forcePush := dialog.Replace(
+		progress.NewDialogContent(progress.New("Force push", func(report func(string)) error {
+			return push(true, report)
+		})),
+		onClose,
+		dialog.CenterDisplayMode,
+	)
+
+	msg := "Do you want to force push?\n\n" +
+		"The remote branch is overwritten, unless it changed since the last fetch."
+	confirmDialog := confirm.NewDialogContent(
+		confirm.New("Force push", msg).
+			WithOnConfirmCmd(forcePush),
+	)
+	return dialog.Show(confirmDialog, nil, dialog.CenterDisplayMode)
+}
+
+func push(forceWithLease bool, report func(string)) error {
+	opts, err := git.CurrentBranchPushOptions()
+	if err != nil {
+		return err
+	}
+	opts.ForceWithLease = forceWithLease
+	return git.Push(opts, report)
+}
+
+func showProgress(title string, operation progress.Operation, onClose tea.Cmd) tea.Cmd {
+	content := progress.NewDialogContent(progress.New(title, operation))
+	return dialog.Show(content, onClose, dialog.CenterDisplayMode)
+}
+
+// BackgroundFetchedMsg is sent after FetchInBackground finished.
+type BackgroundFetchedMsg struct {
+	Err error
+}
+
+// FetchInBackground quietly fetches the upstream remote of the current branch
+// without asking for credentials. It does nothing if there is no upstream.
+func FetchInBackground() tea.Msg {
+	remote, err := git.UpstreamRemote()
+	if err != nil || len(remote) == 0 {
+		return BackgroundFetchedMsg{Err: err}
+	}
+	err = git.Fetch(git.FetchOptions{Remote: remote, IsBackground: true}, nil)
+	return BackgroundFetchedMsg{Err: err}
+}
@@ -1,12 +1,16 @@
 package stash
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/michaelhass/gitglance/internal/core/err"
 	"github.com/michaelhass/gitglance/internal/core/git"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/confirm"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/form"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
 )
 
 type EntryCmdType byte
@@ -16,6 +20,10 @@ const (
 	AppliedEntryCmdType
 	PoppedEntryCmdType
 	DroppedEntryCmdType
+	AppliedWithIndexEntryCmdType
+	BranchedEntryCmdType
+	RenamedEntryCmdType
+	ClearedCmdType
 )
 
 type EntryCmdExecuted struct {
@@ -38,6 +46,14 @@ func (ece EntryCmdExecuted) ErrorTitle() string {
 		return "Pop stash entry error"
 	case DroppedEntryCmdType:
 		return "Drop stash entry error"
+	case AppliedWithIndexEntryCmdType:
+		return "Apply stash entry with index error"
+	case BranchedEntryCmdType:
+		return "Create branch from stash entry error"
+	case RenamedEntryCmdType:
+		return "Rename stash entry error"
+	case ClearedCmdType:
+		return "Clear stash error"
 	}
 	return ""
 }
@@ -54,28 +70,63 @@ func executionErrHandler(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
-func CreateWithUntracked(msg string) tea.Cmd {
+func Create(opts git.CreateStashOpts) tea.Cmd {
 	return func() tea.Msg {
-		opts := git.CreateStashOpts{}
-		opts.WithUntracked = true
-		opts.Message = msg
 		err := git.CreateStash(opts)
 		return EntryCmdExecuted{CmdType: CreatedEntryCmdType, err: err}
 	}
 }
-func ShowCreateWithUntrackedConfirmation(onClose tea.Cmd) tea.Cmd {
-	confirmModel := confirm.
-		New("Stash", "Do you want to stash all changes?").
-		WithTextInput(
-			"Message...",
-			func(message string) tea.Cmd {
-				return CreateWithUntracked(message)
-			},
-		)
-	confirmDialog := confirm.
-		NewDialogContent(confirmModel).
-		WithErrHandler(executionErrHandler)
-	return dialog.Show(confirmDialog, onClose, dialog.CenterDisplayMode)
+
+const (
+	messageFieldKey       = "message"
+	withUntrackedFieldKey = "untracked"
+	withIgnoredFieldKey   = "ignored"
+	keepIndexFieldKey     = "keepIndex"
+	stagedOnlyFieldKey    = "stagedOnly"
+	onlySelectedFieldKey  = "onlySelected"
+)
+
+// ShowCreateDialog shows a form to configure and create a new stash entry.
+// If paths are given, the user can choose to only stash those files.
+func ShowCreateDialog(paths []string, onClose tea.Cmd) tea.Cmd {
+	createForm := form.New("Stash", "Do you want to stash your changes?").
+		WithTextField(messageFieldKey, "Message", "Message...", "").
+		WithCheckbox(withUntrackedFieldKey, "Include untracked files", true).
+		WithCheckbox(withIgnoredFieldKey, "Include ignored files", false).
+		WithCheckbox(keepIndexFieldKey, "Keep staged changes", false).
+		WithCheckbox(stagedOnlyFieldKey, "Only staged changes", false)
+
+	if len(paths) == 1 {
+		createForm = createForm.WithCheckbox(onlySelectedFieldKey, fmt.Sprintf("Only %s", paths[0]), false)
+	} else if len(paths) > 1 {
+		createForm = createForm.WithCheckbox(onlySelectedFieldKey, fmt.Sprintf("Only %d selected files", len(paths)), false)
+	}
+
+	createForm = createForm.WithOnSubmit(func(values form.Values) tea.Cmd {
+		opts := git.CreateStashOpts{
+			Message:       values.Text(messageFieldKey),
+			WithAll:       values.IsChecked(withIgnoredFieldKey),
+			KeepIndex:     values.IsChecked(keepIndexFieldKey),
+			StagedOnly:    values.IsChecked(stagedOnlyFieldKey),
+			WithUntracked: values.IsChecked(withUntrackedFieldKey),
+		}
+		// Untracked files are part of all files.
+		if opts.WithAll {
+			opts.WithUntracked = false
+		}
+		// Git refuses to combine --staged with untracked files, but
+		// the default of the untracked checkbox should not be in the way.
+		if opts.StagedOnly && !opts.WithAll {
+			opts.WithUntracked = false
+		}
+		if values.IsChecked(onlySelectedFieldKey) {
+			opts.Paths = paths
+		}
+		return Create(opts)
+	})
+
+	dc := form.NewDialogContent(createForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, onClose, dialog.CenterDisplayMode)
 }
 
 type LoadedMsg struct {
@@ -88,9 +139,45 @@ func Load() tea.Msg {
 	return LoadedMsg{Stash: stash, Err: err}
 }
 
-func ShowListDialog(onClose tea.Cmd) tea.Cmd {
+// ShowListDialog shows the stash entries next to the files and diff of the focused entry.
+// Diffs larger than the limit are collapsed.
+func ShowListDialog(limit diff.Limit, onClose tea.Cmd) tea.Cmd {
 	stashList := NewListModel("Stash", DefaultKeyMap(), DefaultListItemHandler())
-	return dialog.Show(NewApplyDialogConent(stashList), onClose, dialog.CenterDisplayMode)
+	return dialog.Show(NewApplyDialogConent(stashList, limit), onClose, dialog.FullScreenDisplayMode)
+}
+
+type filesLoadedMsg struct {
+	entry git.StashEntry
+	files git.FileStatusList
+	err   error
+}
+
+func loadFiles(entry git.StashEntry) tea.Cmd {
+	return func() tea.Msg {
+		files, err := git.StashEntryFiles(entry)
+		return filesLoadedMsg{entry: entry, files: files, err: err}
+	}
+}
+
+// diffLoadedMsg contains the diff of the file at the path in the entry.
+// The path is empty for an empty diff, which is shown without a focused file.
+type diffLoadedMsg struct {
+	entry      git.StashEntry
+	path       string
+	diff       string
+	inspection diff.Inspection
+	err        error
+}
+
+func loadDiff(entry git.StashEntry, file git.FileStatus) tea.Cmd {
+	return func() tea.Msg {
+		rawDiff, err := git.StashEntryDiff(entry, file)
+		return diffLoadedMsg{entry: entry, path: file.Path, diff: rawDiff, inspection: diff.Inspect(rawDiff), err: err}
+	}
+}
+
+func showEmptyDiff() tea.Msg {
+	return diffLoadedMsg{}
 }
 
 func showActionConfirmation(confirmCmd tea.Cmd, msg string) tea.Cmd {
@@ -132,3 +219,83 @@ func dropEntry(entry git.StashEntry) tea.Cmd {
 		}
 	}
 }
+
+func applyEntryWithIndex(entry git.StashEntry) tea.Cmd {
+	return func() tea.Msg {
+		err := git.ApplyStashEntryWithIndex(entry)
+		return EntryCmdExecuted{
+			CmdType: AppliedWithIndexEntryCmdType,
+			Entry:   entry,
+			err:     err,
+		}
+	}
+}
+
+func branchFromEntry(entry git.StashEntry, name string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.BranchFromStashEntry(entry, name)
+		return EntryCmdExecuted{
+			CmdType: BranchedEntryCmdType,
+			Entry:   entry,
+			err:     err,
+		}
+	}
+}
+
+func renameEntry(entry git.StashEntry, message string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.RenameStashEntry(entry, message)
+		return EntryCmdExecuted{
+			CmdType: RenamedEntryCmdType,
+			Entry:   entry,
+			err:     err,
+		}
+	}
+}
+
+// clearConfirmationText needs to be typed to clear the stash.
+const clearConfirmationText = "clear"
+
+func clearStash(confirmation string) tea.Cmd {
+	return func() tea.Msg {
+		if confirmation != clearConfirmationText {
+			return EntryCmdExecuted{
+				CmdType: ClearedCmdType,
+				err:     fmt.Errorf("Type '%s' to clear the stash.", clearConfirmationText),
+			}
+		}
+		err := git.ClearStash()
+		return EntryCmdExecuted{CmdType: ClearedCmdType, err: err}
+	}
+}
+
+func showBranchDialog(entry git.StashEntry) tea.Cmd {
+	confirmModel := confirm.
+		New("Stash", fmt.Sprintf("Create branch from entry?\n%s", entry.Message())).
+		WithTextInput(
+			"Branch name...",
+			func(name string) tea.Cmd {
+				return branchFromEntry(entry, name)
+			},
+		)
+	dc := confirm.NewDialogContent(confirmModel).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func showRenameDialog(entry git.StashEntry) tea.Cmd {
+	renameForm := form.New("Rename stash entry", "").
+		WithTextField(messageFieldKey, "Message", "Message...", entry.Message()).
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			return renameEntry(entry, values.Text(messageFieldKey))
+		})
+	dc := form.NewDialogContent(renameForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func showClearConfirmation() tea.Cmd {
+	confirmModel := confirm.
+		New("Stash", fmt.Sprintf("Drop all stash entries?\nThis can't be undone. Type '%s' to confirm.", clearConfirmationText)).
+		WithTextInput(clearConfirmationText, clearStash)
+	dc := confirm.NewDialogContent(confirmModel).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
@@ -0,0 +1,36 @@
+package stash
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/container"
+)
+
+// ListContainerContent is a wrapper to use the stash list as container.Content.
+type ListContainerContent struct {
+	ListModel
+}
+
+func NewListContainerContent(model ListModel) ListContainerContent {
+	return ListContainerContent{ListModel: model}
+}
+
+func (c ListContainerContent) Update(msg tea.Msg) (container.Content, tea.Cmd) {
+	model, cmd := c.ListModel.Update(msg)
+	c.ListModel = model
+	return c, cmd
+}
+
+func (c ListContainerContent) UpdateFocus(isFocused bool) (container.Content, tea.Cmd) {
+	model, cmd := c.ListModel.UpdateFocus(isFocused)
+	c.ListModel = model
+	return c, cmd
+}
+
+func (c ListContainerContent) SetSize(width, height int) container.Content {
+	c.ListModel = c.ListModel.SetSize(width, height)
+	return c
+}
+
+func (c ListContainerContent) IsCapturingInput() bool {
+	return c.ListModel.IsFiltering()
+}
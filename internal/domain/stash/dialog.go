@@ -4,72 +4,220 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/container"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
-	"github.com/michaelhass/gitglance/internal/core/ui/style"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
 )
 
+type section byte
+
 const (
-	titleHeight   = 1
-	borderPadding = 1
-	borderWidth   = 1
+	entriesSection section = iota
+	filesSection
+	diffSection
 )
 
-var (
-	titleStyle  = style.Title.Height(titleHeight)
-	borderStyle = style.FocusBorder.PaddingLeft(borderPadding).PaddingRight(borderPadding)
+const (
+	entriesWidthFactor       float32 = 0.45
+	filesHeightFactor        float32 = 0.35
+	sectionsHorizontalMargin int     = 1
 )
 
-func NewApplyDialogConent(stashList ListModel) ListDialogContent {
-	stashList.listModel, _ = stashList.listModel.UpdateFocus(true)
-	return ListDialogContent{ListModel: stashList}
+// ListDialogContent shows the stash entries on the left. The files and the diff
+// of the selected file of the focused entry are shown on the right.
+type ListDialogContent struct {
+	sections       [3]container.Model
+	focusedSection section
+	keys           KeyMap
+}
+
+func NewApplyDialogConent(stashList ListModel, limit diff.Limit) ListDialogContent {
+	entries, _ := container.New(NewListContainerContent(stashList)).UpdateFocus(true)
+
+	return ListDialogContent{
+		sections: [3]container.Model{
+			entries,
+			list.NewContainer(newFileList()),
+			container.New(diff.NewContent(diff.New().WithLimit(limit))),
+		},
+		keys: newKeyMap(),
+	}
 }
 
 func (dc ListDialogContent) Init() tea.Cmd {
-	return dc.ListModel.Init()
+	var cmds []tea.Cmd
+	for _, section := range dc.sections {
+		cmds = append(cmds, section.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (dc ListDialogContent) Update(msg tea.Msg) (dialog.Content, tea.Cmd) {
-	model, cmd := dc.ListModel.Update(msg)
-	dc.ListModel = model
-	return dc, cmd
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		if len(msg.Stash) == 0 {
+			dc = dc.setFiles(filesLoadedMsg{})
+			dc = dc.setDiff(diffLoadedMsg{})
+		}
+	case filesLoadedMsg:
+		if entry, ok := dc.entries().FocusedEntry(); ok && entry.Index() == msg.entry.Index() {
+			dc = dc.setFiles(msg)
+			cmds = append(cmds, dc.loadDiffIfNeeded())
+		}
+	case diffLoadedMsg:
+		// The diff of a previously focused file may be loaded after the diff of the focused one.
+		if dc.isFocusedDiff(msg) {
+			dc = dc.setDiff(msg)
+		}
+	case tea.KeyMsg:
+		if dc.IsCapturingInput() {
+			break
+		}
+		switch {
+		case key.Matches(msg, dc.keys.left):
+			if dc.focusedSection > entriesSection {
+				dc.focusedSection -= 1
+			}
+		case key.Matches(msg, dc.keys.right):
+			if dc.focusedSection < diffSection {
+				dc.focusedSection += 1
+			}
+		}
+	}
+
+	for i, section := range dc.sections {
+		updatedSection, cmd := section.UpdateFocus(i == int(dc.focusedSection))
+		cmds = append(cmds, cmd)
+
+		updatedSection, cmd = updatedSection.Update(msg)
+		cmds = append(cmds, cmd)
+
+		dc.sections[i] = updatedSection
+	}
+
+	return dc, tea.Batch(cmds...)
 }
 
 func (dc ListDialogContent) View() string {
-	if !dc.IsReady() {
+	if !dc.entries().IsReady() {
 		return ""
 	}
 
-	title := titleStyle.Render(dc.ListModel.Title())
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		dc.sections[entriesSection].View(),
+		" ",
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			dc.sections[filesSection].View(),
+			dc.sections[diffSection].View(),
+		),
+	)
+}
+
+func (dc ListDialogContent) SetSize(width, height int) dialog.Content {
+	var (
+		entriesWidth = int(float32(width) * entriesWidthFactor)
+		rightWidth   = width - entriesWidth - sectionsHorizontalMargin
+		filesHeight  = int(float32(height) * filesHeightFactor)
+		diffHeight   = height - filesHeight
+	)
+
+	dc.sections[entriesSection] = dc.sections[entriesSection].SetSize(entriesWidth, height)
+	dc.sections[filesSection] = dc.sections[filesSection].SetSize(rightWidth, filesHeight)
+	dc.sections[diffSection] = dc.sections[diffSection].SetSize(rightWidth, diffHeight)
+	return dc
+}
+
+func (dc ListDialogContent) Help() []key.Binding {
+	var keys []key.Binding
+	if keyMap := dc.sections[dc.focusedSection].Content().KeyMap(); keyMap != nil {
+		keys = append(keys, keyMap.ShortHelp()...)
+	}
+	if dc.IsCapturingInput() {
+		return keys
+	}
 
-	var listView string
-	if dc.listModel.ItemsCount() == 0 {
-		listView = "No stash entries"
-	} else {
-		listView = dc.ListModel.View()
+	if dc.focusedSection > entriesSection {
+		keys = append(keys, dc.keys.left)
+	}
+	if dc.focusedSection < diffSection {
+		keys = append(keys, dc.keys.right)
 	}
+	return keys
+}
 
-	return borderStyle.
-		MaxHeight(dc.height).
-		Width(dc.width).
-		Render(
-			lipgloss.JoinVertical(
-				lipgloss.Left,
-				title,
-				"",
-				listView,
-			),
-		)
+func (dc ListDialogContent) IsCapturingInput() bool {
+	return dc.sections[dc.focusedSection].IsCapturingInput()
 }
 
-func (dc ListDialogContent) SetSize(width, height int) dialog.Content {
-	dc.width, dc.height = width, height
+func (dc ListDialogContent) entries() ListModel {
+	content, _ := dc.sections[entriesSection].Content().(ListContainerContent)
+	return content.ListModel
+}
 
-	maxContentHeight := height - titleHeight - 1 - borderPadding*2 - borderWidth*2
-	maxContentWidth := width - borderPadding*2 - borderWidth*2
-	dc.ListModel = dc.ListModel.SetSize(maxContentWidth, maxContentHeight)
+func (dc ListDialogContent) setFiles(msg filesLoadedMsg) ListDialogContent {
+	content, ok := dc.sections[filesSection].Content().(list.ContainerContent)
+	if !ok {
+		return dc
+	}
+	// The focus update of a focused list is handled by the list itself.
+	content.Model, _ = content.SetItems(createFileListItems(msg.entry, msg.files))
+	dc.sections[filesSection] = dc.sections[filesSection].SetContent(content)
+	if msg.err != nil {
+		dc = dc.setDiff(diffLoadedMsg{err: msg.err})
+	}
 	return dc
 }
 
-func (dc ListDialogContent) Help() []key.Binding {
-	return dc.listModel.KeyMap().ShortHelp()
+// loadDiffIfNeeded loads the diff of the focused file, unless the file list is focused.
+// A focused list already requested the diff when its items were set.
+func (dc ListDialogContent) loadDiffIfNeeded() tea.Cmd {
+	if dc.focusedSection == filesSection {
+		return list.ForceFocusUpdate
+	}
+	content, ok := dc.sections[filesSection].Content().(list.ContainerContent)
+	if !ok {
+		return nil
+	}
+	item, err := content.FocusedItem()
+	if err != nil {
+		return showEmptyDiff
+	}
+	if fileItem, ok := item.(fileListItem); ok {
+		return loadDiff(fileItem.entry, fileItem.FileStatus)
+	}
+	return nil
+}
+
+// isFocusedDiff reports whether the diff is the diff of the focused file of the focused entry.
+func (dc ListDialogContent) isFocusedDiff(msg diffLoadedMsg) bool {
+	content, ok := dc.sections[filesSection].Content().(list.ContainerContent)
+	if !ok {
+		return false
+	}
+	item, err := content.FocusedItem()
+	if err != nil {
+		return len(msg.path) == 0
+	}
+	fileItem, ok := item.(fileListItem)
+	if !ok {
+		return false
+	}
+	entry, ok := dc.entries().FocusedEntry()
+	return ok && entry.Index() == msg.entry.Index() &&
+		fileItem.entry.Index() == msg.entry.Index() && fileItem.Path == msg.path
+}
+
+func (dc ListDialogContent) setDiff(msg diffLoadedMsg) ListDialogContent {
+	content, ok := dc.sections[diffSection].Content().(diff.ContainerContent)
+	if !ok {
+		return dc
+	}
+	content.Model = content.SetInspectedContent(msg.diff, msg.inspection, msg.err)
+	dc.sections[diffSection] = dc.sections[diffSection].SetContent(content)
+	return dc
 }
@@ -0,0 +1,48 @@
+package stash
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+	filelist "github.com/michaelhass/gitglance/internal/core/ui/components/list/file"
+)
+
+// fileListItem is a file of a stash entry.
+type fileListItem struct {
+	filelist.Item
+	entry git.StashEntry
+}
+
+func newFileList() list.Model {
+	keyMap := list.NewKeyMap("", "", "")
+	keyMap.Enter.SetEnabled(false)
+	keyMap.All.SetEnabled(false)
+	keyMap.Edit.SetEnabled(false)
+	keyMap.Delete.SetEnabled(false)
+
+	itemHandler := func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.FocusItemMsg:
+			if item, ok := msg.Item.(fileListItem); ok {
+				return loadDiff(item.entry, item.FileStatus)
+			}
+			return nil
+		case list.NoItemsMsg:
+			return showEmptyDiff
+		}
+		return nil
+	}
+
+	return list.New("Files", itemHandler, keyMap)
+}
+
+func createFileListItems(entry git.StashEntry, files git.FileStatusList) []list.Item {
+	items := make([]list.Item, len(files))
+	for i, file := range files {
+		items[i] = fileListItem{
+			Item:  filelist.NewItem(file, string(file.UnstagedStatusCode)),
+			entry: entry,
+		}
+	}
+	return items
+}
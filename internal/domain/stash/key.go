@@ -0,0 +1,40 @@
+package stash
+
+import "github.com/charmbracelet/bubbles/key"
+
+type KeyMap struct {
+	left  key.Binding
+	right key.Binding
+}
+
+func newKeyMap() KeyMap {
+	return KeyMap{
+		left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+		),
+		right: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+		),
+	}
+}
+
+// entryKeyMap contains the custom keys of the stash list.
+type entryKeyMap struct {
+	apply          key.Binding
+	applyWithIndex key.Binding
+	branch         key.Binding
+	rename         key.Binding
+	clear          key.Binding
+}
+
+func newEntryKeyMap() entryKeyMap {
+	return entryKeyMap{
+		apply:          key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "apply")),
+		applyWithIndex: key.NewBinding(key.WithKeys("A"), key.WithHelp("⇧+a", "apply with index")),
+		branch:         key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "branch")),
+		rename:         key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rename")),
+		clear:          key.NewBinding(key.WithKeys("C"), key.WithHelp("⇧+c", "clear")),
+	}
+}
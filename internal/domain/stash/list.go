@@ -3,18 +3,34 @@ package stash
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/michaelhass/gitglance/internal/core/git"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
 )
 
+const dateFormat = "2006-01-02 15:04"
+
 type ListItem struct {
-	entry git.StashEntry
+	entry       git.StashEntry
+	branchWidth int
 }
 
 func (item ListItem) Render() string {
-	return item.entry.Message()
+	untracked := " "
+	if item.entry.HasUntracked() {
+		untracked = "u"
+	}
+	return fmt.Sprintf(
+		"[%s] %s  %-*s  %s  %s",
+		untracked,
+		item.entry.Hash(),
+		item.branchWidth,
+		item.entry.Branch(),
+		item.entry.Date().Format(dateFormat),
+		item.entry.Message(),
+	)
 }
 
 type ListModel struct {
@@ -23,8 +39,14 @@ type ListModel struct {
 }
 
 func DefaultListItemHandler() list.ItemHandler {
+	keys := newEntryKeyMap()
 	return func(msg tea.Msg) tea.Cmd {
 		switch msg := msg.(type) {
+		case list.FocusItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return loadFiles(item.entry)
+			}
+			return nil
 		case list.SelectItemMsg:
 			if item, ok := msg.Item.(ListItem); ok {
 				return showActionConfirmation(
@@ -42,11 +64,27 @@ func DefaultListItemHandler() list.ItemHandler {
 			}
 			return nil
 		case list.CustomItemMsg:
-			if item, ok := msg.Item.(ListItem); ok {
+			item, ok := msg.Item.(ListItem)
+			if !ok {
+				return nil
+			}
+			switch {
+			case key.Matches(msg.KeyMsg, keys.apply):
 				return showActionConfirmation(
 					applyEntry(item.entry),
 					fmt.Sprintf("Apply entry?\n%s", item.entry.Message()),
 				)
+			case key.Matches(msg.KeyMsg, keys.applyWithIndex):
+				return showActionConfirmation(
+					applyEntryWithIndex(item.entry),
+					fmt.Sprintf("Apply entry and restore staged changes?\n%s", item.entry.Message()),
+				)
+			case key.Matches(msg.KeyMsg, keys.branch):
+				return showBranchDialog(item.entry)
+			case key.Matches(msg.KeyMsg, keys.rename):
+				return showRenameDialog(item.entry)
+			case key.Matches(msg.KeyMsg, keys.clear):
+				return showClearConfirmation()
 			}
 		}
 		return nil
@@ -57,8 +95,13 @@ func DefaultKeyMap() list.KeyMap {
 	keyMap := list.NewKeyMap("", "pop", "drop")
 	keyMap.All.SetEnabled(false)
 	keyMap.Edit.SetEnabled(false)
+	keys := newEntryKeyMap()
 	keyMap.CustomKeys = []key.Binding{
-		key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "apply")),
+		keys.apply,
+		keys.applyWithIndex,
+		keys.branch,
+		keys.rename,
+		keys.clear,
 	}
 	return keyMap
 }
@@ -80,11 +123,7 @@ func (sl ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	if msg, ok := msg.(LoadedMsg); ok {
-		var items []list.Item
-		for _, entry := range msg.Stash {
-			items = append(items, ListItem{entry: entry})
-		}
-		listModel, cmd := sl.listModel.SetItems(items)
+		listModel, cmd := sl.listModel.SetItems(createListItems(msg.Stash))
 		sl.listModel = listModel
 		sl.isReady = true
 		cmds = append(cmds, cmd)
@@ -97,7 +136,16 @@ func (sl ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 	return sl, tea.Batch(cmds...)
 }
 
+func (sl ListModel) UpdateFocus(isFocused bool) (ListModel, tea.Cmd) {
+	listModel, cmd := sl.listModel.UpdateFocus(isFocused)
+	sl.listModel = listModel
+	return sl, cmd
+}
+
 func (sl ListModel) View() string {
+	if sl.isReady && sl.listModel.IsEmpty() {
+		return "No stash entries"
+	}
 	return sl.listModel.View()
 }
 
@@ -110,11 +158,38 @@ func (sl ListModel) Title() string {
 	return sl.listModel.Title()
 }
 
+func (sl ListModel) KeyMap() help.KeyMap {
+	return sl.listModel.KeyMap()
+}
+
+// IsFiltering reports whether the filter of the list is being typed.
+func (sl ListModel) IsFiltering() bool {
+	return sl.listModel.IsFiltering()
+}
+
 func (sl ListModel) IsReady() bool {
 	return sl.isReady
 }
 
-type ListDialogContent struct {
-	ListModel
-	width, height int
+// FocusedEntry returns the currently focused stash entry.
+func (sl ListModel) FocusedEntry() (git.StashEntry, bool) {
+	item, err := sl.listModel.FocusedItem()
+	if err != nil {
+		return git.StashEntry{}, false
+	}
+	listItem, ok := item.(ListItem)
+	return listItem.entry, ok
+}
+
+func createListItems(stash git.Stash) []list.Item {
+	var branchWidth int
+	for _, entry := range stash {
+		branchWidth = max(branchWidth, len(entry.Branch()))
+	}
+
+	items := make([]list.Item, len(stash))
+	for i, entry := range stash {
+		items[i] = ListItem{entry: entry, branchWidth: branchWidth}
+	}
+	return items
 }
@@ -0,0 +1,53 @@
+// Package submodule provides ui to update submodules and to open them in a nested session.
+package submodule
+
+import (
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/progress"
+)
+
+// ShowUpdate initializes and updates all submodules recursively.
+func ShowUpdate(onClose tea.Cmd) tea.Cmd {
+	updateDialog := progress.NewDialogContent(progress.New("Update submodules", func(report func(string)) error {
+		return git.UpdateSubmodules(report)
+	}))
+	return dialog.Show(updateDialog, onClose, dialog.CenterDisplayMode)
+}
+
+// SessionClosedMsg is sent once a nested session ended.
+type SessionClosedMsg struct {
+	Err error
+}
+
+// OpenSession starts a nested gitglance session inside of the submodule at path.
+// The current session continues once the nested session is quit.
+func OpenSession(path string) tea.Cmd {
+	return func() tea.Msg {
+		submodulePath, err := git.SubmodulePath(path)
+		if err != nil {
+			return showError(err)()
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return showError(err)()
+		}
+
+		cmd := exec.Command(executable, os.Args[1:]...)
+		cmd.Dir = submodulePath
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return SessionClosedMsg{Err: err}
+		})()
+	}
+}
+
+func showError(err error) tea.Cmd {
+	content := info.NewDialogContent(info.New("Submodule error", err.Error()))
+	return dialog.Show(content, nil, dialog.CenterDisplayMode)
+}
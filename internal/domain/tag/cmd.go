@@ -0,0 +1,155 @@
+// Package tag provides ui to list, create, delete, push and check out tags.
+package tag
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/err"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/confirm"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/form"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/listdialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/progress"
+)
+
+type CmdType byte
+
+const (
+	CreatedCmdType CmdType = iota
+	DeletedCmdType
+	CheckedOutCmdType
+)
+
+type CmdExecuted struct {
+	CmdType CmdType
+	Tag     git.Tag
+	err     error
+}
+
+func (ce CmdExecuted) Err() error {
+	return ce.err
+}
+
+func (ce CmdExecuted) ErrorTitle() string {
+	switch ce.CmdType {
+	case CreatedCmdType:
+		return "Create tag error"
+	case DeletedCmdType:
+		return "Delete tag error"
+	case CheckedOutCmdType:
+		return "Checkout tag error"
+	}
+	return ""
+}
+
+func (ce CmdExecuted) ErrorDescription() string {
+	return ce.err.Error()
+}
+
+func executionErrHandler(msg tea.Msg) tea.Cmd {
+	if errMsg, ok := msg.(err.Msg); ok && errMsg.Err() != nil {
+		errDialogContent := info.NewDialogContentWithErrMsg(errMsg)
+		return dialog.Show(errDialogContent, nil, dialog.CenterDisplayMode)
+	}
+	return nil
+}
+
+type LoadedMsg struct {
+	Tags []git.Tag
+	Err  error
+}
+
+func Load() tea.Msg {
+	tags, err := git.GetTags()
+	return LoadedMsg{Tags: tags, Err: err}
+}
+
+// ShowListDialog shows all tags.
+func ShowListDialog(onClose tea.Cmd) tea.Cmd {
+	tagList := NewListModel("Tags", DefaultKeyMap(), DefaultListItemHandler())
+	return dialog.Show(listdialog.NewDialogContent(tagList, "No tags"), onClose, dialog.FullScreenDisplayMode)
+}
+
+const (
+	nameFieldKey     = "name"
+	targetFieldKey   = "target"
+	messageFieldKey  = "message"
+	isSignedFieldKey = "signed"
+)
+
+// ShowCreateDialog shows a form to create a tag on the given target revision.
+// The tag is annotated if a message is entered.
+func ShowCreateDialog(target string, onClose tea.Cmd) tea.Cmd {
+	if len(target) == 0 {
+		target = "HEAD"
+	}
+
+	createForm := form.New("Create tag", "").
+		WithTextField(nameFieldKey, "Name", "v1.0.0", "").
+		WithTextField(targetFieldKey, "Commit", "HEAD", target).
+		WithTextField(messageFieldKey, "Message", "Leave empty for a lightweight tag", "").
+		WithCheckbox(isSignedFieldKey, "Sign", false).
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			return create(git.CreateTagOpts{
+				Name:     values.Text(nameFieldKey),
+				Target:   values.Text(targetFieldKey),
+				Message:  values.Text(messageFieldKey),
+				IsSigned: values.IsChecked(isSignedFieldKey),
+			})
+		})
+
+	dc := form.NewDialogContent(createForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, onClose, dialog.CenterDisplayMode)
+}
+
+func showActionConfirmation(confirmCmd tea.Cmd, msg string) tea.Cmd {
+	dc := confirm.NewDialogContent(confirm.New("Tag", msg).
+		WithOnConfirmCmd(confirmCmd)).
+		WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func showPushProgress(title string, operation progress.Operation) tea.Cmd {
+	content := progress.NewDialogContent(progress.New(title, operation))
+	return dialog.Show(content, Load, dialog.CenterDisplayMode)
+}
+
+func create(opts git.CreateTagOpts) tea.Cmd {
+	return func() tea.Msg {
+		err := git.CreateTag(opts)
+		return CmdExecuted{
+			CmdType: CreatedCmdType,
+			Tag:     git.Tag{Name: opts.Name, Message: opts.Message},
+			err:     err,
+		}
+	}
+}
+
+func deleteTag(tag git.Tag) tea.Cmd {
+	return func() tea.Msg {
+		err := git.DeleteTag(tag.Name)
+		return CmdExecuted{CmdType: DeletedCmdType, Tag: tag, err: err}
+	}
+}
+
+func checkout(tag git.Tag) tea.Cmd {
+	return func() tea.Msg {
+		err := git.CheckoutTag(tag.Name)
+		return CmdExecuted{CmdType: CheckedOutCmdType, Tag: tag, err: err}
+	}
+}
+
+func push(tag git.Tag) tea.Cmd {
+	return showPushProgress(fmt.Sprintf("Push %s", tag.Name), func(report func(string)) error {
+		return git.PushTag(tag.Name, report)
+	})
+}
+
+func pushAll() tea.Cmd {
+	return showPushProgress("Push all tags", func(report func(string)) error {
+		return git.PushAllTags(report)
+	})
+}
@@ -0,0 +1,190 @@
+package tag
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+)
+
+const dateFormat = "2006-01-02"
+
+type ListItem struct {
+	tag       git.Tag
+	nameWidth int
+}
+
+func (item ListItem) Render() string {
+	kind := "l"
+	if item.tag.IsAnnotated {
+		kind = "a"
+	}
+	return fmt.Sprintf(
+		"[%s] %-*s  %s  %s  %s",
+		kind,
+		item.nameWidth,
+		item.tag.Name,
+		item.tag.Target,
+		item.tag.Date.Format(dateFormat),
+		item.tag.Message,
+	)
+}
+
+type ListModel struct {
+	listModel list.Model
+	keys      KeyMap
+	isReady   bool
+}
+
+// KeyMap contains the list keys and the keys that don't need a focused tag.
+type KeyMap struct {
+	list.KeyMap
+	create  key.Binding
+	pushAll key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return append(km.KeyMap.ShortHelp(), km.create, km.pushAll)
+}
+
+func DefaultListItemHandler() list.ItemHandler {
+	return func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.SelectItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return showActionConfirmation(
+					checkout(item.tag),
+					fmt.Sprintf("Check out %s?\n\nThis will detach HEAD.", item.tag.Name),
+				)
+			}
+			return nil
+		case list.DeleteItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return showActionConfirmation(
+					deleteTag(item.tag),
+					fmt.Sprintf("Delete local tag %s?", item.tag.Name),
+				)
+			}
+			return nil
+		case list.CustomItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return push(item.tag)
+			}
+		}
+		return nil
+	}
+}
+
+func DefaultKeyMap() KeyMap {
+	keyMap := list.NewKeyMap("", "checkout", "delete")
+	keyMap.All.SetEnabled(false)
+	keyMap.Edit.SetEnabled(false)
+	keyMap.CustomKeys = []key.Binding{
+		key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "push")),
+	}
+	return KeyMap{
+		KeyMap:  keyMap,
+		create:  key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+		pushAll: key.NewBinding(key.WithKeys("P"), key.WithHelp("⇧+p", "push all")),
+	}
+}
+
+func NewListModel(title string, keyMap KeyMap, itemHandler list.ItemHandler) ListModel {
+	listModel := list.New(
+		title,
+		itemHandler,
+		keyMap.KeyMap,
+	)
+	return ListModel{listModel: listModel, keys: keyMap}
+}
+
+func (tl ListModel) Init() tea.Cmd {
+	return Load
+}
+
+func (tl ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		listModel, cmd := tl.listModel.SetItems(createListItems(msg.Tags))
+		tl.listModel = listModel
+		tl.isReady = true
+		cmds = append(cmds, cmd)
+	case tea.KeyMsg:
+		if tl.IsFiltering() {
+			break
+		}
+		switch {
+		case key.Matches(msg, tl.keys.create):
+			return tl, ShowCreateDialog("HEAD", Load)
+		case key.Matches(msg, tl.keys.pushAll):
+			return tl, pushAll()
+		}
+	}
+
+	listModel, cmd := tl.listModel.Update(msg)
+	tl.listModel = listModel
+	cmds = append(cmds, cmd)
+
+	return tl, tea.Batch(cmds...)
+}
+
+func (tl ListModel) View() string {
+	return tl.listModel.View()
+}
+
+func (tl ListModel) SetSize(width, height int) ListModel {
+	tl.listModel = tl.listModel.SetSize(width, height)
+	return tl
+}
+
+func (tl ListModel) Title() string {
+	return tl.listModel.Title()
+}
+
+// IsFiltering reports whether the filter of the list is being typed.
+func (tl ListModel) IsFiltering() bool {
+	return tl.listModel.IsFiltering()
+}
+
+func (tl ListModel) UpdateFocus(isFocused bool) (ListModel, tea.Cmd) {
+	listModel, cmd := tl.listModel.UpdateFocus(isFocused)
+	tl.listModel = listModel
+	return tl, cmd
+}
+
+func (tl ListModel) IsEmpty() bool {
+	return tl.listModel.ItemsCount() == 0
+}
+
+// Help returns the keys of the filter while it is typed.
+func (tl ListModel) Help() []key.Binding {
+	if tl.IsFiltering() {
+		return tl.listModel.KeyMap().ShortHelp()
+	}
+	return tl.keys.ShortHelp()
+}
+
+func (tl ListModel) IsReady() bool {
+	return tl.isReady
+}
+
+func (tl ListModel) KeyMap() KeyMap {
+	return tl.keys
+}
+
+func createListItems(tags []git.Tag) []list.Item {
+	var nameWidth int
+	for _, tag := range tags {
+		nameWidth = max(nameWidth, len(tag.Name))
+	}
+
+	items := make([]list.Item, len(tags))
+	for i, tag := range tags {
+		items[i] = ListItem{tag: tag, nameWidth: nameWidth}
+	}
+	return items
+}
@@ -0,0 +1,194 @@
+// Package worktree provides ui to list, create, remove, lock and switch work trees.
+package worktree
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/err"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/confirm"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/form"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/info"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/listdialog"
+)
+
+type CmdType byte
+
+const (
+	CreatedCmdType CmdType = iota
+	RemovedCmdType
+	LockedCmdType
+	UnlockedCmdType
+	// SwitchedCmdType is executed after the process changed into the work tree.
+	// The whole app needs to be reloaded.
+	SwitchedCmdType
+)
+
+type CmdExecuted struct {
+	CmdType  CmdType
+	Worktree git.Worktree
+	err      error
+}
+
+func (ce CmdExecuted) Err() error {
+	return ce.err
+}
+
+func (ce CmdExecuted) ErrorTitle() string {
+	switch ce.CmdType {
+	case CreatedCmdType:
+		return "Create work tree error"
+	case RemovedCmdType:
+		return "Remove work tree error"
+	case LockedCmdType:
+		return "Lock work tree error"
+	case UnlockedCmdType:
+		return "Unlock work tree error"
+	case SwitchedCmdType:
+		return "Switch work tree error"
+	}
+	return ""
+}
+
+func (ce CmdExecuted) ErrorDescription() string {
+	return ce.err.Error()
+}
+
+func executionErrHandler(msg tea.Msg) tea.Cmd {
+	if errMsg, ok := msg.(err.Msg); ok && errMsg.Err() != nil {
+		errDialogContent := info.NewDialogContentWithErrMsg(errMsg)
+		return dialog.Show(errDialogContent, nil, dialog.CenterDisplayMode)
+	}
+	return nil
+}
+
+type LoadedMsg struct {
+	Worktrees []git.Worktree
+	Err       error
+}
+
+func Load() tea.Msg {
+	worktrees, err := git.GetWorktrees()
+	return LoadedMsg{Worktrees: worktrees, Err: err}
+}
+
+// ShowListDialog shows all work trees.
+func ShowListDialog(onClose tea.Cmd) tea.Cmd {
+	worktreeList := NewListModel("Worktrees", DefaultKeyMap(), DefaultListItemHandler())
+	return dialog.Show(listdialog.NewDialogContent(worktreeList, "No work trees"), onClose, dialog.FullScreenDisplayMode)
+}
+
+const (
+	pathFieldKey      = "path"
+	branchFieldKey    = "branch"
+	newBranchFieldKey = "newBranch"
+	reasonFieldKey    = "reason"
+	forceFieldKey     = "force"
+)
+
+// ShowCreateDialog shows a form to create a work tree.
+func ShowCreateDialog(onClose tea.Cmd) tea.Cmd {
+	createForm := form.New("Create work tree", "").
+		WithTextField(pathFieldKey, "Path", "../review", "").
+		WithTextField(branchFieldKey, "Branch", "Branch to check out or start the new branch at", "").
+		WithTextField(newBranchFieldKey, "New branch", "Leave empty to check out the branch", "").
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			return create(git.CreateWorktreeOpts{
+				Path:      values.Text(pathFieldKey),
+				Branch:    values.Text(branchFieldKey),
+				NewBranch: values.Text(newBranchFieldKey),
+			})
+		})
+
+	dc := form.NewDialogContent(createForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, onClose, dialog.CenterDisplayMode)
+}
+
+func showRemoveDialog(worktree git.Worktree) tea.Cmd {
+	removeForm := form.New("Worktree", fmt.Sprintf("Remove work tree?\n%s", worktree.Path)).
+		WithCheckbox(forceFieldKey, "Force (discards local changes)", false).
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			return remove(worktree, values.IsChecked(forceFieldKey))
+		})
+
+	dc := form.NewDialogContent(removeForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func showLockDialog(worktree git.Worktree) tea.Cmd {
+	if worktree.IsLocked {
+		return showActionConfirmation(
+			unlock(worktree),
+			fmt.Sprintf("Unlock work tree?\n%s", worktree.Path),
+		)
+	}
+
+	lockForm := form.New("Worktree", fmt.Sprintf("Lock work tree?\n%s", worktree.Path)).
+		WithTextField(reasonFieldKey, "Reason", "Optional", "").
+		WithOnSubmit(func(values form.Values) tea.Cmd {
+			return lock(worktree, values.Text(reasonFieldKey))
+		})
+
+	dc := form.NewDialogContent(lockForm).WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func showActionConfirmation(confirmCmd tea.Cmd, msg string) tea.Cmd {
+	dc := confirm.NewDialogContent(confirm.New("Worktree", msg).
+		WithOnConfirmCmd(confirmCmd)).
+		WithErrHandler(executionErrHandler)
+	return dialog.Show(dc, Load, dialog.CenterDisplayMode)
+}
+
+func create(opts git.CreateWorktreeOpts) tea.Cmd {
+	return func() tea.Msg {
+		err := git.CreateWorktree(opts)
+		return CmdExecuted{
+			CmdType:  CreatedCmdType,
+			Worktree: git.Worktree{Path: opts.Path, Branch: opts.NewBranch},
+			err:      err,
+		}
+	}
+}
+
+func remove(worktree git.Worktree, force bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if worktree.IsCurrent {
+			err = errors.New("Can't remove the current work tree. Please switch to another one first.")
+		} else {
+			err = git.RemoveWorktree(worktree.Path, force)
+		}
+		return CmdExecuted{CmdType: RemovedCmdType, Worktree: worktree, err: err}
+	}
+}
+
+func lock(worktree git.Worktree, reason string) tea.Cmd {
+	return func() tea.Msg {
+		err := git.LockWorktree(worktree.Path, reason)
+		return CmdExecuted{CmdType: LockedCmdType, Worktree: worktree, err: err}
+	}
+}
+
+func unlock(worktree git.Worktree) tea.Cmd {
+	return func() tea.Msg {
+		err := git.UnlockWorktree(worktree.Path)
+		return CmdExecuted{CmdType: UnlockedCmdType, Worktree: worktree, err: err}
+	}
+}
+
+// switchTo changes into the work tree. The app reloads on success.
+func switchTo(worktree git.Worktree) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if worktree.IsBare {
+			err = errors.New("Can't switch to a bare repository.")
+		} else {
+			err = git.SwitchWorktree(worktree.Path)
+		}
+		return CmdExecuted{CmdType: SwitchedCmdType, Worktree: worktree, err: err}
+	}
+}
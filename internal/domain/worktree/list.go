@@ -0,0 +1,206 @@
+package worktree
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+)
+
+const shortHashLength = 7
+
+type ListItem struct {
+	worktree  git.Worktree
+	pathWidth int
+}
+
+func (item ListItem) Render() string {
+	return fmt.Sprintf(
+		"[%s] %-*s  %s",
+		item.flags(),
+		item.pathWidth,
+		item.worktree.Path,
+		item.ref(),
+	)
+}
+
+// flags returns `*` for the current, `L` for a locked and `P` for a prunable work tree.
+func (item ListItem) flags() string {
+	flags := []byte("   ")
+	if item.worktree.IsCurrent {
+		flags[0] = '*'
+	}
+	if item.worktree.IsLocked {
+		flags[1] = 'L'
+	}
+	if item.worktree.IsPrunable {
+		flags[2] = 'P'
+	}
+	return string(flags)
+}
+
+// ref returns the branch or the commit of a detached HEAD.
+func (item ListItem) ref() string {
+	switch {
+	case item.worktree.IsBare:
+		return "(bare)"
+	case len(item.worktree.Branch) > 0:
+		return item.worktree.Branch
+	case len(item.worktree.Head) > shortHashLength:
+		return fmt.Sprintf("(detached %s)", item.worktree.Head[:shortHashLength])
+	}
+	return fmt.Sprintf("(detached %s)", item.worktree.Head)
+}
+
+type ListModel struct {
+	listModel list.Model
+	keys      KeyMap
+	isReady   bool
+}
+
+// KeyMap contains the list keys and the keys that don't need a focused work tree.
+type KeyMap struct {
+	list.KeyMap
+	create key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return append(km.KeyMap.ShortHelp(), km.create)
+}
+
+func DefaultListItemHandler() list.ItemHandler {
+	return func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.SelectItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return switchTo(item.worktree)
+			}
+			return nil
+		case list.DeleteItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return showRemoveDialog(item.worktree)
+			}
+			return nil
+		case list.CustomItemMsg:
+			if item, ok := msg.Item.(ListItem); ok {
+				return showLockDialog(item.worktree)
+			}
+		}
+		return nil
+	}
+}
+
+func DefaultKeyMap() KeyMap {
+	keyMap := list.NewKeyMap("", "switch", "remove")
+	keyMap.All.SetEnabled(false)
+	keyMap.Edit.SetEnabled(false)
+	keyMap.CustomKeys = []key.Binding{
+		key.NewBinding(key.WithKeys("L"), key.WithHelp("⇧+l", "lock/unlock")),
+	}
+	return KeyMap{
+		KeyMap: keyMap,
+		create: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new")),
+	}
+}
+
+func NewListModel(title string, keyMap KeyMap, itemHandler list.ItemHandler) ListModel {
+	listModel := list.New(
+		title,
+		itemHandler,
+		keyMap.KeyMap,
+	)
+	return ListModel{listModel: listModel, keys: keyMap}
+}
+
+func (wl ListModel) Init() tea.Cmd {
+	return Load
+}
+
+func (wl ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case LoadedMsg:
+		listModel, cmd := wl.listModel.SetItems(createListItems(msg.Worktrees))
+		wl.listModel = listModel
+		wl.isReady = true
+		cmds = append(cmds, cmd)
+	case CmdExecuted:
+		// Switching is executed without confirmation dialog, which would show the error.
+		if msg.CmdType == SwitchedCmdType {
+			return wl, executionErrHandler(msg)
+		}
+	case tea.KeyMsg:
+		if wl.IsFiltering() {
+			break
+		}
+		if key.Matches(msg, wl.keys.create) {
+			return wl, ShowCreateDialog(Load)
+		}
+	}
+
+	listModel, cmd := wl.listModel.Update(msg)
+	wl.listModel = listModel
+	cmds = append(cmds, cmd)
+
+	return wl, tea.Batch(cmds...)
+}
+
+func (wl ListModel) View() string {
+	return wl.listModel.View()
+}
+
+func (wl ListModel) SetSize(width, height int) ListModel {
+	wl.listModel = wl.listModel.SetSize(width, height)
+	return wl
+}
+
+func (wl ListModel) Title() string {
+	return wl.listModel.Title()
+}
+
+// IsFiltering reports whether the filter of the list is being typed.
+func (wl ListModel) IsFiltering() bool {
+	return wl.listModel.IsFiltering()
+}
+
+func (wl ListModel) UpdateFocus(isFocused bool) (ListModel, tea.Cmd) {
+	listModel, cmd := wl.listModel.UpdateFocus(isFocused)
+	wl.listModel = listModel
+	return wl, cmd
+}
+
+func (wl ListModel) IsEmpty() bool {
+	return wl.listModel.ItemsCount() == 0
+}
+
+// Help returns the keys of the filter while it is typed.
+func (wl ListModel) Help() []key.Binding {
+	if wl.IsFiltering() {
+		return wl.listModel.KeyMap().ShortHelp()
+	}
+	return wl.keys.ShortHelp()
+}
+
+func (wl ListModel) IsReady() bool {
+	return wl.isReady
+}
+
+func (wl ListModel) KeyMap() KeyMap {
+	return wl.keys
+}
+
+func createListItems(worktrees []git.Worktree) []list.Item {
+	var pathWidth int
+	for _, worktree := range worktrees {
+		pathWidth = max(pathWidth, len(worktree.Path))
+	}
+
+	items := make([]list.Item, len(worktrees))
+	for i, worktree := range worktrees {
+		items[i] = ListItem{worktree: worktree, pathWidth: pathWidth}
+	}
+	return items
+}
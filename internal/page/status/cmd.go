@@ -3,6 +3,7 @@ package status
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/michaelhass/gitglance/internal/core/editor"
@@ -12,8 +13,16 @@ import (
 	"github.com/michaelhass/gitglance/internal/core/ui/components/dialog/confirm"
 	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
 	filelist "github.com/michaelhass/gitglance/internal/core/ui/components/list/file"
+	"github.com/michaelhass/gitglance/internal/domain/blame"
 	"github.com/michaelhass/gitglance/internal/domain/commit"
+	"github.com/michaelhass/gitglance/internal/domain/diff"
+	"github.com/michaelhass/gitglance/internal/domain/history"
+	"github.com/michaelhass/gitglance/internal/domain/ignore"
+	"github.com/michaelhass/gitglance/internal/domain/remote"
 	"github.com/michaelhass/gitglance/internal/domain/stash"
+	"github.com/michaelhass/gitglance/internal/domain/submodule"
+	"github.com/michaelhass/gitglance/internal/domain/tag"
+	"github.com/michaelhass/gitglance/internal/domain/worktree"
 )
 
 type initializedMsg struct {
@@ -21,7 +30,8 @@ type initializedMsg struct {
 	diffMsg   loadedDiffMsg
 }
 
-func initializeStatus() tea.Cmd {
+// initializeStatus loads the status with the options and the diff of the first unstaged file with the format.
+func initializeStatus(opts git.StatusOptions, format git.DiffFormat) tea.Cmd {
 	return func() tea.Msg {
 		var (
 			msg            initializedMsg
@@ -31,7 +41,7 @@ func initializeStatus() tea.Cmd {
 			err            error
 		)
 
-		workTreeStatus, err = git.Status()
+		workTreeStatus, err = git.Status(opts)
 		if err != nil {
 			msg.statusMsg.Err = err
 			return msg
@@ -44,10 +54,11 @@ func initializeStatus() tea.Cmd {
 		}
 
 		isUntracked = unstagedFiles[0].IsUntracked()
-		diffMsg, ok := diffFile(
+		diffMsg, ok := loadDiff(
 			git.DiffOptions{
 				FilePath:    unstagedFiles[0].Path,
 				IsUntracked: isUntracked,
+				Format:      format,
 			},
 		)().(loadedDiffMsg)
 		if !ok {
@@ -77,62 +88,117 @@ type statusUpdateMsg struct {
 	WorkTreeStatus git.WorkTreeStatus
 }
 
+// refreshStatusMsg requests to load the status with the options of the model.
+type refreshStatusMsg struct{}
+
 func refreshStatus() tea.Cmd {
-	return tea.Sequence(
-		updateWorkTreeStatus,
-		list.ForceFocusUpdate,
-	)
+	return func() tea.Msg {
+		return refreshStatusMsg{}
+	}
 }
 
-func stageFile(path string) tea.Cmd {
+// loadStatus loads the status with the options and updates the diff of the focused file afterwards.
+func loadStatus(opts git.StatusOptions) tea.Cmd {
 	return tea.Sequence(
-		workTreeUpdateWithCmd(func() error {
-			return git.StageFile(path)
-		}),
+		updateWorkTreeStatus(opts),
 		list.ForceFocusUpdate,
 	)
 }
 
 func stageAll() tea.Cmd {
-	return tea.Sequence(
-		workTreeUpdateWithCmd(func() error {
-			return git.StageAll()
-		}),
-		list.ForceFocusUpdate,
-	)
+	return workTreeUpdateWithCmd(func() error {
+		return git.StageAll()
+	})
 }
 
-func unstageFile(path string) tea.Cmd {
-	return tea.Sequence(
-		workTreeUpdateWithCmd(func() error {
-			return git.UnstageFile(path)
-		}),
-		list.ForceFocusUpdate,
-	)
+func stageFiles(paths []string) tea.Cmd {
+	return workTreeUpdateWithCmd(func() error {
+		return git.StageFiles(paths)
+	})
 }
 
 func unstageAll() tea.Cmd {
-	return tea.Sequence(
-		workTreeUpdateWithCmd(func() error {
-			return git.UnstageAll()
-		}),
-		list.ForceFocusUpdate,
-	)
+	return workTreeUpdateWithCmd(func() error {
+		return git.UnstageAll()
+	})
+}
+
+func unstageFiles(paths []string) tea.Cmd {
+	return workTreeUpdateWithCmd(func() error {
+		return git.UnstageFiles(paths)
+	})
 }
 
 func deleteFile(fileItem filelist.Item) tea.Cmd {
 	title := "Reset"
 	msg := fmt.Sprintf("Do you want to reset?\n\n%s", fileItem.String())
-	confirmCmd := tea.Sequence(
-		workTreeUpdateWithCmd(func() error {
-			return git.ResetFile(fileItem.Path, fileItem.IsUntracked())
-		}),
-		list.ForceFocusUpdate,
+	confirmCmd := workTreeUpdateWithCmd(func() error {
+		return git.ResetFile(fileItem.Path, fileItem.IsUntracked())
+	})
+	confirmDialog := confirm.NewDialogContent(confirm.New(title, msg).WithOnConfirmCmd(confirmCmd))
+	return dialog.Show(confirmDialog, nil, dialog.CenterDisplayMode)
+}
+
+func deleteDir(dirItem filelist.DirItem) tea.Cmd {
+	title := "Reset"
+	msg := fmt.Sprintf("Do you want to reset all files in the directory?\n\n%s", dirItem.String())
+	confirmCmd := workTreeUpdateWithCmd(func() error {
+		return git.ResetFiles(dirItem.Files)
+	})
+	confirmDialog := confirm.NewDialogContent(confirm.New(title, msg).WithOnConfirmCmd(confirmCmd))
+	return dialog.Show(confirmDialog, nil, dialog.CenterDisplayMode)
+}
+
+// deleteFiles resets the files and all files in the directories of the items at once.
+func deleteFiles(items []list.Item) tea.Cmd {
+	var (
+		files        git.FileStatusList
+		descriptions []string
 	)
+	for _, item := range items {
+		switch item := item.(type) {
+		case filelist.Item:
+			files = append(files, item.FileStatus)
+			descriptions = append(descriptions, item.String())
+		case filelist.DirItem:
+			files = append(files, item.Files...)
+			descriptions = append(descriptions, item.String())
+		}
+	}
+
+	title := "Reset"
+	msg := fmt.Sprintf("Do you want to reset %d items?\n\n%s", len(descriptions), listDescriptions(descriptions))
+	confirmCmd := workTreeUpdateWithCmd(func() error {
+		return git.ResetFiles(files)
+	})
 	confirmDialog := confirm.NewDialogContent(confirm.New(title, msg).WithOnConfirmCmd(confirmCmd))
 	return dialog.Show(confirmDialog, nil, dialog.CenterDisplayMode)
 }
 
+// maxListedDescriptions is the number of items a confirmation lists, before it summarizes the rest.
+const maxListedDescriptions = 10
+
+// listDescriptions lists the descriptions line by line and summarizes those beyond maxListedDescriptions.
+func listDescriptions(descriptions []string) string {
+	if len(descriptions) <= maxListedDescriptions {
+		return strings.Join(descriptions, "\n")
+	}
+	listed := strings.Join(descriptions[:maxListedDescriptions], "\n")
+	return fmt.Sprintf("%s\nand %d more", listed, len(descriptions)-maxListedDescriptions)
+}
+
+type toggleDirMsg struct {
+	section section
+	path    string
+}
+
+// toggleDir collapses or expands the directory at path in the file section.
+func toggleDir(section section, path string) tea.Cmd {
+	return func() tea.Msg {
+		return toggleDirMsg{section: section, path: path}
+	}
+}
+
 func openFile(path string) tea.Cmd {
 	return tea.ExecProcess(
 		editor.OpenFileCmdDefault(
@@ -146,55 +212,75 @@ func openFile(path string) tea.Cmd {
 	)
 }
 
+// workTreeUpdateWithCmd changes the work tree and refreshes the status afterwards.
 func workTreeUpdateWithCmd(cmdFunc func() error) tea.Cmd {
 	return func() tea.Msg {
 		if err := cmdFunc(); err != nil {
 			return statusUpdateMsg{Err: err}
 		}
 
-		return updateWorkTreeStatus()
+		return refreshStatusMsg{}
 	}
 }
 
-func updateWorkTreeStatus() tea.Msg {
-	var (
-		workTreeStatus git.WorkTreeStatus
-		msg            statusUpdateMsg
-		err            error
-	)
-	workTreeStatus, err = git.Status()
-	if err != nil {
-		msg.Err = err
+func updateWorkTreeStatus(opts git.StatusOptions) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			workTreeStatus git.WorkTreeStatus
+			msg            statusUpdateMsg
+			err            error
+		)
+		workTreeStatus, err = git.Status(opts)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		msg.WorkTreeStatus = workTreeStatus
 		return msg
 	}
-	msg.WorkTreeStatus = workTreeStatus
-	return msg
 }
 
 type loadedDiffMsg struct {
 	Err  error
 	Diff string
+	// Options the diff was loaded with. Nil, if no file is diffed.
+	Options    *git.DiffOptions
+	Inspection diff.Inspection
 }
 
 func showEmptyDiff() tea.Msg {
 	return loadedDiffMsg{}
 }
 
-func diffFile(opt git.DiffOptions) func() tea.Msg {
+// diffFileMsg requests to load the diff with the format of the model.
+type diffFileMsg struct {
+	options git.DiffOptions
+}
+
+func diffFile(opt git.DiffOptions) tea.Cmd {
+	return func() tea.Msg {
+		return diffFileMsg{options: opt}
+	}
+}
+
+// loadDiff loads the diff with the options, which contain the format.
+func loadDiff(opt git.DiffOptions) tea.Cmd {
 	return func() tea.Msg {
 		var (
-			msg  loadedDiffMsg
-			err  error
-			diff string
+			msg     loadedDiffMsg
+			err     error
+			rawDiff string
 		)
 
+		msg.Options = &opt
 		msg.Diff = opt.FilePath
-		diff, err = git.Diff(opt)
+		rawDiff, err = git.Diff(opt)
 		if err != nil {
 			msg.Err = err
 			return msg
 		}
-		msg.Diff = diff
+		msg.Diff = rawDiff
+		msg.Inspection = diff.Inspect(rawDiff)
 
 		return msg
 	}
@@ -205,10 +291,54 @@ func showCommitDialog(branchName string, files git.FileStatusList) tea.Cmd {
 	return dialog.Show(content, refreshStatus(), dialog.CenterDisplayMode)
 }
 
-func showStashAllConfirmation() tea.Cmd {
-	return stash.ShowCreateWithUntrackedConfirmation(refreshStatus())
+func showCreateStashDialog(selectedPaths []string) tea.Cmd {
+	return stash.ShowCreateDialog(selectedPaths, refreshStatus())
+}
+
+func showStashListDialog(limit diff.Limit) tea.Cmd {
+	return stash.ShowListDialog(limit, refreshStatus())
+}
+
+func showFetchDialog() tea.Cmd {
+	return remote.ShowFetch(refreshStatus())
+}
+
+func showPullDialog() tea.Cmd {
+	return remote.ShowPull(refreshStatus())
+}
+
+func showPushDialog() tea.Cmd {
+	return remote.ShowPush(refreshStatus())
+}
+
+func showForcePushConfirmation() tea.Cmd {
+	return remote.ShowForcePushConfirmation(refreshStatus())
+}
+
+func showTagListDialog() tea.Cmd {
+	return tag.ShowListDialog(refreshStatus())
+}
+
+func showUpdateSubmodulesDialog() tea.Cmd {
+	return submodule.ShowUpdate(refreshStatus())
+}
+
+func openSubmoduleSession(path string) tea.Cmd {
+	return submodule.OpenSession(path)
+}
+
+func showWorktreeListDialog() tea.Cmd {
+	return worktree.ShowListDialog(refreshStatus())
+}
+
+func showBlameDialog(path string, limit diff.Limit) tea.Cmd {
+	return blame.ShowDialog(path, limit, nil)
+}
+
+func showHistoryDialog(path string, limit diff.Limit) tea.Cmd {
+	return history.ShowDialog(path, limit, nil)
 }
 
-func showStashListDialog() tea.Cmd {
-	return stash.ShowListDialog(refreshStatus())
+func showIgnoreDialog(paths []string) tea.Cmd {
+	return ignore.ShowDialog(paths, refreshStatus())
 }
@@ -6,17 +6,31 @@ import (
 )
 
 type KeyMap struct {
-	up            key.Binding
-	down          key.Binding
-	left          key.Binding
-	right         key.Binding
-	commit        key.Binding
-	focusUnstaged key.Binding
-	focusStaged   key.Binding
-	focusDiff     key.Binding
-	refresh       key.Binding
-	stash         key.Binding
-	showStash     key.Binding
+	up               key.Binding
+	down             key.Binding
+	left             key.Binding
+	right            key.Binding
+	commit           key.Binding
+	focusUnstaged    key.Binding
+	focusStaged      key.Binding
+	focusDiff        key.Binding
+	refresh          key.Binding
+	stash            key.Binding
+	showStash        key.Binding
+	fetch            key.Binding
+	pull             key.Binding
+	push             key.Binding
+	forcePush        key.Binding
+	showTags         key.Binding
+	showWorktrees    key.Binding
+	blame            key.Binding
+	history          key.Binding
+	ignore           key.Binding
+	showIgnored      key.Binding
+	untrackedFiles   key.Binding
+	treeMode         key.Binding
+	openSubmodule    key.Binding
+	updateSubmodules key.Binding
 
 	quit key.Binding
 
@@ -69,6 +83,62 @@ func newKeyMap() KeyMap {
 			key.WithKeys("S"),
 			key.WithHelp("⇧+s", "show Stash"),
 		),
+		fetch: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "fetch"),
+		),
+		pull: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pull"),
+		),
+		push: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("⇧+p", "push"),
+		),
+		forcePush: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "force push"),
+		),
+		showTags: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "tags"),
+		),
+		blame: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "blame"),
+		),
+		history: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("⇧+h", "history"),
+		),
+		ignore: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "ignore"),
+		),
+		showIgnored: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("⇧+i", "show ignored"),
+		),
+		untrackedFiles: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "untracked: all"),
+		),
+		treeMode: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("⇧+t", "tree view"),
+		),
+		showWorktrees: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("⇧+w", "worktrees"),
+		),
+		openSubmodule: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open submodule"),
+		),
+		updateSubmodules: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("⇧+u", "update submodules"),
+		),
 		quit: key.NewBinding(
 			key.WithKeys("ctrl+c"),
 			key.WithHelp("ctrl+c", "quit"),
@@ -80,7 +150,12 @@ func (k KeyMap) ShortHelp() []key.Binding {
 	allKeys := []key.Binding{
 		k.focusUnstaged, k.focusStaged, k.focusDiff,
 		k.stash, k.showStash,
+		k.showTags, k.showWorktrees,
+		k.blame, k.history, k.ignore,
+		k.showIgnored, k.untrackedFiles, k.treeMode,
+		k.openSubmodule, k.updateSubmodules,
 		k.commit,
+		k.fetch, k.pull, k.push, k.forcePush,
 		k.up, k.down, k.left, k.right,
 		k.refresh,
 		k.quit,
@@ -126,3 +201,13 @@ func matchesKey(lhs key.Binding, rhs key.Binding) bool {
 	}
 	return false
 }
+
+// newFoldKey creates the key to collapse and expand directories in tree mode.
+// It is disabled, until tree mode is enabled.
+func newFoldKey() key.Binding {
+	return key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "fold"),
+		key.WithDisabled(),
+	)
+}
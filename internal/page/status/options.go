@@ -0,0 +1,14 @@
+package status
+
+import "github.com/michaelhass/gitglance/internal/core/git"
+
+// nextUntrackedFilesMode cycles through the modes. Git's default is treated as normal.
+func nextUntrackedFilesMode(mode git.UntrackedFilesMode) git.UntrackedFilesMode {
+	switch mode {
+	case git.UntrackedFilesNo:
+		return git.UntrackedFilesNormal
+	case git.UntrackedFilesAll:
+		return git.UntrackedFilesNo
+	}
+	return git.UntrackedFilesAll
+}
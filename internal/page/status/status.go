@@ -15,6 +15,8 @@ import (
 	filelist "github.com/michaelhass/gitglance/internal/core/ui/components/list/file"
 	"github.com/michaelhass/gitglance/internal/core/ui/style"
 	"github.com/michaelhass/gitglance/internal/domain/diff"
+	"github.com/michaelhass/gitglance/internal/domain/remote"
+	"github.com/michaelhass/gitglance/internal/domain/submodule"
 )
 
 type section byte
@@ -23,6 +25,8 @@ const (
 	unstagedSection section = iota
 	stagedSection
 	diffSection
+	// ignoredSection is only shown if ignored files are shown.
+	ignoredSection
 )
 
 const (
@@ -38,7 +42,18 @@ var (
 type Model struct {
 	workTreeStatus git.WorkTreeStatus
 
-	sections [3]container.Model
+	sections [4]container.Model
+	// loadOptions are the options the status is loaded with.
+	loadOptions git.StatusOptions
+	// diffOptions are the options of the shown diff, to load it again in another format.
+	diffOptions *git.DiffOptions
+	// diffFormat is the format to load diffs with for the rest of the session.
+	diffFormat git.DiffFormat
+	// diffLimit is the size of diffs, which are collapsed until they are loaded anyway.
+	diffLimit diff.Limit
+	// isTreeMode shows the unstaged and staged files grouped by directory.
+	isTreeMode    bool
+	collapsedDirs map[dirKey]bool
 
 	help help.Model
 	keys KeyMap
@@ -47,25 +62,38 @@ type Model struct {
 	focusedSection         section
 	lastFocusedFileSection section
 
+	width, height int
+
 	isInitialized bool
 }
 
-func New() Model {
+// New creates the status page. Diffs larger than the limit are collapsed until they are loaded anyway.
+func New(diffLimit diff.Limit) Model {
+	return newModel(git.StatusOptions{}, git.DiffFormat{}, diffLimit)
+}
+
+// Reloaded returns a new model, which keeps the options of the status and the format and limit of diffs,
+// e.g. after switching the work tree.
+func (m Model) Reloaded() Model {
+	return newModel(m.loadOptions, m.diffFormat, m.diffLimit)
+}
+
+func newModel(loadOptions git.StatusOptions, diffFormat git.DiffFormat, diffLimit diff.Limit) Model {
 	unstagedFilesItemHandler := func(msg tea.Msg) tea.Cmd {
 		switch msg := msg.(type) {
 		case list.SelectItemMsg:
-			if item, ok := msg.Item.(filelist.Item); ok {
-				return stageFile(item.Path)
-			}
-			return nil
+			return stageFiles(itemPaths(msg.Items))
 		case list.FocusItemMsg:
-			if item, ok := msg.Item.(filelist.Item); ok {
+			switch item := msg.Item.(type) {
+			case filelist.Item:
 				return diffFile(
 					git.DiffOptions{
 						FilePath:    item.Path,
 						IsUntracked: item.IsUntracked(),
 					},
 				)
+			case filelist.DirItem:
+				return diffFile(git.DiffOptions{FilePath: item.Path, UntrackedPaths: untrackedPaths(item.Files)})
 			}
 			return nil
 		case list.EditItemMsg:
@@ -74,11 +102,25 @@ func New() Model {
 			}
 			return nil
 		case list.DeleteItemMsg:
-			if item, ok := msg.Item.(filelist.Item); ok {
+			if len(msg.Items) > 1 {
+				return deleteFiles(msg.Items)
+			}
+			switch item := msg.Item.(type) {
+			case filelist.Item:
 				return deleteFile(item)
+			case filelist.DirItem:
+				return deleteDir(item)
+			}
+			return nil
+		case list.CustomItemMsg:
+			if item, ok := msg.Item.(filelist.DirItem); ok {
+				return toggleDir(unstagedSection, item.Path)
 			}
 			return nil
 		case list.SelectAllItemMsg:
+			if msg.IsFiltered {
+				return stageFiles(itemPaths(msg.Items))
+			}
 			return stageAll()
 		case list.BottomNoMoreFocusableItems:
 			return focusSection(stagedSection)
@@ -92,20 +134,28 @@ func New() Model {
 	stagedFilesItemHandler := func(msg tea.Msg) tea.Cmd {
 		switch msg := msg.(type) {
 		case list.SelectItemMsg:
-			if item, ok := msg.Item.(filelist.Item); ok {
-				return unstageFile(item.Path)
-			}
-			return nil
+			return unstageFiles(itemPaths(msg.Items))
 		case list.SelectAllItemMsg:
+			if msg.IsFiltered {
+				return unstageFiles(itemPaths(msg.Items))
+			}
 			return unstageAll()
 		case list.FocusItemMsg:
-			if item, ok := msg.Item.(filelist.Item); ok {
+			switch item := msg.Item.(type) {
+			case filelist.Item:
 				return diffFile(
 					git.DiffOptions{
 						FilePath:    item.Path,
 						IsStaged:    true,
 						IsUntracked: item.IsUntracked(),
 					})
+			case filelist.DirItem:
+				return diffFile(git.DiffOptions{FilePath: item.Path, IsStaged: true})
+			}
+			return nil
+		case list.CustomItemMsg:
+			if item, ok := msg.Item.(filelist.DirItem); ok {
+				return toggleDir(stagedSection, item.Path)
 			}
 			return nil
 		case list.EditItemMsg:
@@ -115,6 +165,35 @@ func New() Model {
 			return nil
 		case list.TopNoMoreFocusableItems:
 			return focusSection(unstagedSection)
+		case list.BottomNoMoreFocusableItems:
+			return focusSection(ignoredSection)
+		case list.NoItemsMsg:
+			return showEmptyDiff
+		default:
+			return nil
+		}
+	}
+
+	ignoredFilesItemHandler := func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case list.FocusItemMsg:
+			if item, ok := msg.Item.(filelist.Item); ok {
+				// Ignored files are diffed like untracked files.
+				return diffFile(
+					git.DiffOptions{
+						FilePath:    item.Path,
+						IsUntracked: true,
+					},
+				)
+			}
+			return nil
+		case list.EditItemMsg:
+			if item, ok := msg.Item.(filelist.Item); ok {
+				return openFile(item.Path)
+			}
+			return nil
+		case list.TopNoMoreFocusableItems:
+			return focusSection(stagedSection)
 		case list.NoItemsMsg:
 			return showEmptyDiff
 		default:
@@ -125,36 +204,49 @@ func New() Model {
 	help := help.New()
 	help.ShowAll = false
 
+	var unstagedFileListKeyMap = list.NewKeyMap(
+		"stage all",
+		"stage file",
+		"reset file",
+	).WithMarks("mark same status")
+	unstagedFileListKeyMap.CustomKeys = []key.Binding{newFoldKey()}
 	unstagedFileList := list.NewContainerContent(
-		list.New("Unstaged", unstagedFilesItemHandler, list.NewKeyMap(
-			"stage all",
-			"stage file",
-			"reset file",
-		)),
+		list.New("Unstaged", unstagedFilesItemHandler, unstagedFileListKeyMap),
 	)
 	var stagedFileListKeyMap = list.NewKeyMap(
 		"unstage all",
 		"unstage file",
 		"",
-	)
+	).WithMarks("mark same status")
 	stagedFileListKeyMap.Delete.SetEnabled(false)
+	stagedFileListKeyMap.CustomKeys = []key.Binding{newFoldKey()}
 
 	stagedFileList := list.NewContainerContent(list.New("Staged", stagedFilesItemHandler, stagedFileListKeyMap))
-	diffContent := diff.NewContent(diff.New())
+	diffContent := diff.NewContent(diff.New().WithFormat(diffFormat).WithLimit(diffLimit))
+
+	var ignoredFileListKeyMap = list.NewKeyMap("", "", "")
+	ignoredFileListKeyMap.All.SetEnabled(false)
+	ignoredFileListKeyMap.Enter.SetEnabled(false)
+	ignoredFileListKeyMap.Delete.SetEnabled(false)
+	ignoredFileList := list.NewContainerContent(list.New("Ignored", ignoredFilesItemHandler, ignoredFileListKeyMap))
 
 	return Model{
-		sections: [3]container.Model{
+		sections: [4]container.Model{
 			container.New(unstagedFileList),
 			container.New(stagedFileList),
 			container.New(diffContent),
+			container.New(ignoredFileList),
 		},
-		help: help,
-		keys: newKeyMap(),
+		loadOptions: loadOptions,
+		diffFormat:  diffFormat,
+		diffLimit:   diffLimit,
+		help:        help,
+		keys:        newKeyMap(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	cmds := []tea.Cmd{initializeStatus()}
+	cmds := []tea.Cmd{initializeStatus(m.loadOptions, m.diffFormat)}
 	for _, section := range m.sections {
 		cmds = append(cmds, section.Init())
 	}
@@ -182,11 +274,38 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		model, cmd := m.handleLoadedDiffMsg(msg)
 		m = model
 		cmds = append(cmds, cmd)
+	case diffFileMsg:
+		msg.options.Format = m.diffFormat
+		cmds = append(cmds, loadDiff(msg.options))
+	case diff.FormatChangedMsg:
+		// The shown diff and all following diffs are loaded with the format.
+		m.diffFormat = msg.Format
+		if m.diffOptions != nil {
+			opts := *m.diffOptions
+			opts.Format = m.diffFormat
+			cmds = append(cmds, loadDiff(opts))
+		}
+	case toggleDirMsg:
+		var cmd tea.Cmd
+		m, cmd = m.toggleDir(msg.section, msg.path)
+		cmds = append(cmds, cmd)
 	case focusSectionMsg:
-		m = m.focusSection(msg.section)
+		if msg.section != ignoredSection || m.loadOptions.ShowIgnored {
+			m = m.focusSection(msg.section)
+		}
+	case refreshStatusMsg:
+		cmds = append(cmds, loadStatus(m.loadOptions))
 	case refresh.Msg:
 		cmds = append(cmds, refreshStatus())
+	case remote.BackgroundFetchedMsg:
+		cmds = append(cmds, refreshStatus())
+	case submodule.SessionClosedMsg:
+		cmds = append(cmds, refreshStatus())
 	case tea.KeyMsg:
+		// Keys are added to the filter of the focused list or the search of the diff, while it is typed.
+		if m.sections[m.focusedSection].IsCapturingInput() {
+			break
+		}
 		switch {
 		case key.Matches(msg, m.keys.left):
 			m = m.focusSection(m.lastFocusedFileSection)
@@ -207,9 +326,55 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.refresh):
 			cmds = append(cmds, refreshStatus())
 		case key.Matches(msg, m.keys.stash):
-			cmds = append(cmds, showStashAllConfirmation())
+			cmds = append(cmds, showCreateStashDialog(m.selectedFilePaths()))
 		case key.Matches(msg, key.NewBinding(key.WithKeys("S"))):
-			cmds = append(cmds, showStashListDialog())
+			cmds = append(cmds, showStashListDialog(m.diffLimit))
+		case key.Matches(msg, m.keys.fetch):
+			cmds = append(cmds, showFetchDialog())
+		case key.Matches(msg, m.keys.pull):
+			cmds = append(cmds, showPullDialog())
+		case key.Matches(msg, m.keys.push):
+			cmds = append(cmds, showPushDialog())
+		case key.Matches(msg, m.keys.forcePush):
+			cmds = append(cmds, showForcePushConfirmation())
+		case key.Matches(msg, m.keys.showTags):
+			cmds = append(cmds, showTagListDialog())
+		case key.Matches(msg, m.keys.blame):
+			if file, ok := m.selectedFile(); ok {
+				cmds = append(cmds, showBlameDialog(file.Path, m.diffLimit))
+			}
+		case key.Matches(msg, m.keys.ignore):
+			if paths := m.ignorablePaths(); len(paths) > 0 {
+				cmds = append(cmds, showIgnoreDialog(paths))
+			}
+		case key.Matches(msg, m.keys.showIgnored):
+			m.loadOptions.ShowIgnored = !m.loadOptions.ShowIgnored
+			if !m.loadOptions.ShowIgnored && m.selectedFileSection() == ignoredSection {
+				m = m.focusSection(unstagedSection)
+			}
+			m = m.SetSize(m.width, m.height)
+			cmds = append(cmds, loadStatus(m.loadOptions))
+		case key.Matches(msg, m.keys.treeMode):
+			m.isTreeMode = !m.isTreeMode
+			var unstagedCmd, stagedCmd tea.Cmd
+			m, unstagedCmd = m.setFileItems(unstagedSection)
+			m, stagedCmd = m.setFileItems(stagedSection)
+			cmds = append(cmds, unstagedCmd, stagedCmd)
+		case key.Matches(msg, m.keys.untrackedFiles):
+			m.loadOptions.UntrackedFiles = nextUntrackedFilesMode(m.loadOptions.UntrackedFiles)
+			cmds = append(cmds, loadStatus(m.loadOptions))
+		case key.Matches(msg, m.keys.history):
+			if file, ok := m.selectedFile(); ok {
+				cmds = append(cmds, showHistoryDialog(file.Path, m.diffLimit))
+			}
+		case key.Matches(msg, m.keys.showWorktrees):
+			cmds = append(cmds, showWorktreeListDialog())
+		case key.Matches(msg, m.keys.openSubmodule):
+			if file, ok := m.selectedFile(); ok && file.IsSubmodule() {
+				cmds = append(cmds, openSubmoduleSession(file.Path))
+			}
+		case key.Matches(msg, m.keys.updateSubmodules):
+			cmds = append(cmds, showUpdateSubmodulesDialog())
 		}
 	}
 
@@ -237,11 +402,14 @@ func (m Model) View() string {
 		return "loading..."
 	}
 
-	files := lipgloss.JoinVertical(
-		lipgloss.Top,
+	fileSections := []string{
 		m.sections[unstagedSection].View(),
 		m.sections[stagedSection].View(),
-	)
+	}
+	if m.loadOptions.ShowIgnored {
+		fileSections = append(fileSections, m.sections[ignoredSection].View())
+	}
+	files := lipgloss.JoinVertical(lipgloss.Top, fileSections...)
 
 	sections := lipgloss.JoinHorizontal(
 		lipgloss.Left,
@@ -253,23 +421,31 @@ func (m Model) View() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
 		sections,
-		helpStyle.Render(m.help.View(m.keys)),
+		helpStyle.Render(m.help.View(m.helpKeyMap())),
 	)
 }
 
 func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+
+	fileSectionsCount := 2
+	if m.loadOptions.ShowIgnored {
+		fileSectionsCount = 3
+	}
+
 	var (
 		maxSectionHeight = height - helpHeight
 
 		filesWidth  = int(float32(width) * filesWidthFactor)
-		filesHeight = maxSectionHeight / 2
+		filesHeight = maxSectionHeight / fileSectionsCount
 
 		diffWidth  = width - filesWidth - sectionsHorizontalMargin
-		diffHeight = filesHeight * 2 // don't use maxSectionHeight. Avoids layouting issues if uneven.
+		diffHeight = filesHeight * fileSectionsCount // don't use maxSectionHeight. Avoids layouting issues if uneven.
 	)
 
 	m.sections[unstagedSection] = m.sections[unstagedSection].SetSize(filesWidth, filesHeight)
 	m.sections[stagedSection] = m.sections[stagedSection].SetSize(filesWidth, filesHeight)
+	m.sections[ignoredSection] = m.sections[ignoredSection].SetSize(filesWidth, filesHeight)
 	m.sections[diffSection] = m.sections[diffSection].SetSize(diffWidth, diffHeight)
 
 	m.help.Width = width - helpStyle.GetHorizontalMargins()
@@ -286,18 +462,16 @@ func (m Model) handleStatusUpdateMsg(msg statusUpdateMsg) (Model, tea.Cmd) {
 		return m, exit.WithMsg(msg.Err.Error())
 	}
 
-	if section, ok := m.sections[unstagedSection].Content().(list.ContainerContent); ok {
-		model, cmd := section.SetItems(createListItems(m.workTreeStatus.UnstagedFiles(), false))
-		section.Model = model
-		cmds = append(cmds, cmd)
-		m.sections[unstagedSection] = m.sections[unstagedSection].SetContent(section)
-	}
-	if section, ok := m.sections[stagedSection].Content().(list.ContainerContent); ok {
-		model, cmd := section.SetItems(createListItems(m.workTreeStatus.StagedFiles(), true))
-		model = model.SetTitle(fmt.Sprintf("Staged [%s]", m.workTreeStatus.CleanedBranchName))
+	var cmd tea.Cmd
+	m, cmd = m.setFileItems(unstagedSection)
+	cmds = append(cmds, cmd)
+	m, cmd = m.setFileItems(stagedSection)
+	cmds = append(cmds, cmd)
+	if section, ok := m.sections[ignoredSection].Content().(list.ContainerContent); ok {
+		model, cmd := section.SetItems(createListItems(m.workTreeStatus.IgnoredFiles(), false))
 		section.Model = model
 		cmds = append(cmds, cmd)
-		m.sections[stagedSection] = m.sections[stagedSection].SetContent(section)
+		m.sections[ignoredSection] = m.sections[ignoredSection].SetContent(section)
 	}
 
 	cmds = append(cmds, tea.SetWindowTitle(m.workTreeStatus.CleanedBranchName))
@@ -310,8 +484,9 @@ func (m Model) handleLoadedDiffMsg(msg loadedDiffMsg) (Model, tea.Cmd) {
 	if !ok {
 		return m, nil
 	}
-	section.Model = section.SetContent(msg.Diff, msg.Err)
+	section.Model = section.SetInspectedContent(msg.Diff, msg.Inspection, msg.Err)
 	m.sections[diffSection] = m.sections[diffSection].SetContent(section)
+	m.diffOptions = msg.Options
 	return m, nil
 }
 
@@ -321,10 +496,109 @@ func (m Model) focusSection(section section) Model {
 	return m
 }
 
+// selectedFilePaths returns the path of the focused file in the
+// last focused file section.
+func (m Model) selectedFilePaths() []string {
+	if items := m.markedItems(); len(items) > 0 {
+		return itemPaths(items)
+	}
+	if file, ok := m.selectedFile(); ok {
+		return []string{file.Path}
+	}
+	if dir, ok := m.selectedDir(); ok {
+		return dir.Paths()
+	}
+	return nil
+}
+
+// markedItems returns the marked items in the last focused file section.
+func (m Model) markedItems() []list.Item {
+	content, ok := m.sections[m.selectedFileSection()].Content().(list.ContainerContent)
+	if !ok {
+		return nil
+	}
+	return content.MarkedItems()
+}
+
+// ignorablePaths returns the paths of the marked or the focused files in the unstaged section.
+// Submodules can't be ignored.
+func (m Model) ignorablePaths() []string {
+	if m.selectedFileSection() != unstagedSection {
+		return nil
+	}
+
+	var paths []string
+	if items := m.markedItems(); len(items) > 0 {
+		for _, item := range items {
+			switch item := item.(type) {
+			case filelist.Item:
+				if !item.IsSubmodule() {
+					paths = append(paths, item.Path)
+				}
+			case filelist.DirItem:
+				paths = append(paths, item.Path)
+			}
+		}
+	} else if file, ok := m.selectedFile(); ok && !file.IsSubmodule() {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
+
+// selectedFileSection returns the focused file section or, if the diff is focused,
+// the file section that was focused last.
+func (m Model) selectedFileSection() section {
+	if m.focusedSection == diffSection {
+		return m.lastFocusedFileSection
+	}
+	return m.focusedSection
+}
+
+// selectedFile returns the focused file in the last focused file section.
+func (m Model) selectedFile() (git.FileStatus, bool) {
+	content, ok := m.sections[m.selectedFileSection()].Content().(list.ContainerContent)
+	if !ok {
+		return git.FileStatus{}, false
+	}
+	item, err := content.FocusedItem()
+	if err != nil {
+		return git.FileStatus{}, false
+	}
+	fileItem, ok := item.(filelist.Item)
+	return fileItem.FileStatus, ok
+}
+
+// helpKeyMap only describes the keys of the filter or search, while it is typed.
+func (m Model) helpKeyMap() help.KeyMap {
+	if m.sections[m.focusedSection].IsCapturingInput() {
+		return m.sections[m.focusedSection].Content().KeyMap()
+	}
+	return m.keys
+}
+
 func (m Model) updateKeys() KeyMap {
 	keys := m.keys
 	keys.additionalKeyMap = m.sections[m.focusedSection].Content().KeyMap()
 
+	file, hasSelectedFile := m.selectedFile()
+	keys.openSubmodule.SetEnabled(hasSelectedFile && file.IsSubmodule())
+	keys.blame.SetEnabled(hasSelectedFile && !file.IsUntracked() && !file.IsIgnored() && !file.IsSubmodule())
+	keys.history.SetEnabled(hasSelectedFile && !file.IsUntracked() && !file.IsIgnored())
+	keys.ignore.SetEnabled(len(m.ignorablePaths()) > 0)
+	keys.updateSubmodules.SetEnabled(m.workTreeStatus.HasSubmodules)
+
+	if m.isTreeMode {
+		keys.treeMode.SetHelp("⇧+t", "flat view")
+	} else {
+		keys.treeMode.SetHelp("⇧+t", "tree view")
+	}
+	if m.loadOptions.ShowIgnored {
+		keys.showIgnored.SetHelp("⇧+i", "hide ignored")
+	} else {
+		keys.showIgnored.SetHelp("⇧+i", "show ignored")
+	}
+	keys.untrackedFiles.SetHelp("u", fmt.Sprintf("untracked: %s", nextUntrackedFilesMode(m.loadOptions.UntrackedFiles)))
+
 	switch m.focusedSection {
 	case unstagedSection:
 		keys.left.SetEnabled(false)
@@ -332,7 +606,7 @@ func (m Model) updateKeys() KeyMap {
 		keys.focusUnstaged.SetEnabled(false)
 		keys.focusStaged.SetEnabled(true)
 		keys.focusDiff.SetEnabled(true)
-	case stagedSection:
+	case stagedSection, ignoredSection:
 		keys.left.SetEnabled(false)
 		keys.right.SetEnabled(true)
 		keys.focusUnstaged.SetEnabled(true)
@@ -341,7 +615,7 @@ func (m Model) updateKeys() KeyMap {
 	case diffSection:
 		keys.left.SetEnabled(true)
 		keys.right.SetEnabled(false)
-		isUnstagedFocusedLast := m.lastFocusedFileSection == unstagedSection
+		isUnstagedFocusedLast := m.lastFocusedFileSection != stagedSection
 		keys.focusUnstaged.SetEnabled(isUnstagedFocusedLast)
 		keys.focusStaged.SetEnabled(!isUnstagedFocusedLast)
 		keys.focusDiff.SetEnabled(false)
@@ -350,6 +624,52 @@ func (m Model) updateKeys() KeyMap {
 	return keys
 }
 
+// unstagedTitle shows the untracked files mode, unless git's default is used.
+func unstagedTitle(mode git.UntrackedFilesMode) string {
+	if len(mode) == 0 {
+		return "Unstaged"
+	}
+	return fmt.Sprintf("Unstaged [untracked: %s]", mode)
+}
+
+// branchTitle returns the branch name, together with the number of commits
+// it is ahead (↑) and behind (↓) its upstream.
+func branchTitle(workTreeStatus git.WorkTreeStatus) string {
+	title := workTreeStatus.CleanedBranchName
+	if workTreeStatus.Ahead > 0 {
+		title = fmt.Sprintf("%s ↑%d", title, workTreeStatus.Ahead)
+	}
+	if workTreeStatus.Behind > 0 {
+		title = fmt.Sprintf("%s ↓%d", title, workTreeStatus.Behind)
+	}
+	return title
+}
+
+// itemPaths returns the paths of the file and directory items.
+func itemPaths(items []list.Item) []string {
+	var paths []string
+	for _, item := range items {
+		switch item := item.(type) {
+		case filelist.Item:
+			paths = append(paths, item.Path)
+		case filelist.DirItem:
+			paths = append(paths, item.Paths()...)
+		}
+	}
+	return paths
+}
+
+// untrackedPaths returns the paths of the untracked files.
+func untrackedPaths(files git.FileStatusList) []string {
+	var paths []string
+	for _, file := range files {
+		if file.IsUntracked() {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths
+}
+
 func createListItems(fileStatusList git.FileStatusList, isStaged bool) []list.Item {
 	items := make([]list.Item, len(fileStatusList))
 
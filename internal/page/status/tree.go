@@ -0,0 +1,129 @@
+package status
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/michaelhass/gitglance/internal/core/filetree"
+	"github.com/michaelhass/gitglance/internal/core/git"
+	"github.com/michaelhass/gitglance/internal/core/ui/components/list"
+	filelist "github.com/michaelhass/gitglance/internal/core/ui/components/list/file"
+)
+
+// dirKey identifies a directory of the file tree of a section.
+type dirKey struct {
+	section section
+	path    string
+}
+
+// setFileItems shows the unstaged or staged files as a list or, in tree mode, as a tree.
+func (m Model) setFileItems(fileSection section) (Model, tea.Cmd) {
+	section, ok := m.sections[fileSection].Content().(list.ContainerContent)
+	if !ok {
+		return m, nil
+	}
+
+	var (
+		files    git.FileStatusList
+		isStaged = fileSection == stagedSection
+		title    string
+	)
+	if isStaged {
+		files = m.workTreeStatus.StagedFiles()
+		title = fmt.Sprintf("Staged [%s]", branchTitle(m.workTreeStatus))
+	} else {
+		files = m.workTreeStatus.UnstagedFiles()
+		title = unstagedTitle(m.loadOptions.UntrackedFiles)
+	}
+
+	var items []list.Item
+	if m.isTreeMode {
+		isCollapsed := func(path string) bool {
+			return m.collapsedDirs[dirKey{section: fileSection, path: path}]
+		}
+		items = createTreeListItems(files, isStaged, isCollapsed)
+	} else {
+		items = createListItems(files, isStaged)
+	}
+
+	if keys, ok := section.KeyMap().(list.KeyMap); ok {
+		keys.CustomKeys = append([]key.Binding(nil), keys.CustomKeys...)
+		for i := range keys.CustomKeys {
+			keys.CustomKeys[i].SetEnabled(m.isTreeMode)
+		}
+		section.Model = section.SetKeyMap(keys)
+	}
+
+	model, cmd := section.SetItems(items)
+	section.Model = model.SetTitle(title)
+	m.sections[fileSection] = m.sections[fileSection].SetContent(section)
+	return m, cmd
+}
+
+// toggleDir collapses or expands the directory at path in the file section.
+func (m Model) toggleDir(fileSection section, path string) (Model, tea.Cmd) {
+	collapsedDirs := make(map[dirKey]bool, len(m.collapsedDirs)+1)
+	for key, isCollapsed := range m.collapsedDirs {
+		collapsedDirs[key] = isCollapsed
+	}
+	key := dirKey{section: fileSection, path: path}
+	if collapsedDirs[key] {
+		delete(collapsedDirs, key)
+	} else {
+		collapsedDirs[key] = true
+	}
+	m.collapsedDirs = collapsedDirs
+	return m.setFileItems(fileSection)
+}
+
+// selectedDir returns the focused directory in the last focused file section.
+func (m Model) selectedDir() (filelist.DirItem, bool) {
+	content, ok := m.sections[m.selectedFileSection()].Content().(list.ContainerContent)
+	if !ok {
+		return filelist.DirItem{}, false
+	}
+	item, err := content.FocusedItem()
+	if err != nil {
+		return filelist.DirItem{}, false
+	}
+	dirItem, ok := item.(filelist.DirItem)
+	return dirItem, ok
+}
+
+func createTreeListItems(
+	fileStatusList git.FileStatusList,
+	isStaged bool,
+	isCollapsed func(path string) bool,
+) []list.Item {
+	statusCode := func(fileStatus git.FileStatus) git.StatusCode {
+		if isStaged {
+			return fileStatus.StagedStatusCode
+		}
+		return fileStatus.UnstagedStatusCode
+	}
+
+	paths := make([]string, len(fileStatusList))
+	for i, fs := range fileStatusList {
+		paths[i] = fs.Path
+	}
+
+	var (
+		entries = filetree.Build(paths).Flatten(isCollapsed)
+		items   = make([]list.Item, len(entries))
+	)
+	for i, entry := range entries {
+		if !entry.IsDir() {
+			fs := fileStatusList[entry.Index]
+			items[i] = filelist.NewTreeItem(fs, string(statusCode(fs)), entry.Name, entry.Depth)
+			continue
+		}
+
+		var files git.FileStatusList
+		for _, idx := range entry.FileIndices() {
+			files = append(files, fileStatusList[idx])
+		}
+		items[i] = filelist.NewDirItem(entry.Path, entry.Name, entry.Depth, entry.IsCollapsed, files, statusCode)
+	}
+	return items
+}